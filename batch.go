@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/tanvir-rifat007/graph-ai-search/search"
+)
+
+// tally is what one algorithm did over all the scenarios of a batch
+type tally struct {
+	solved, optimal, failed int
+	explored                int
+	time                    time.Duration
+}
+
+// treeSearches walk every path to a cell again instead of remembering the
+// cell, on a benchmark map they don't finish. "all" leaves them out, they
+// still run when they are named
+var treeSearches = map[int]bool{
+	search.DLS:     true,
+	search.IDDFS:   true,
+	search.IDASTAR: true,
+}
+
+// runBatch solves every scenario of a Moving AI scenario file with each of
+// the algorithms ("all" is every registered one but the treeSearches) and
+// prints a line for every result and a summary for every algorithm. the moves
+// are the ones of the benchmark, 8 directions without cutting a wall corner.
+// limit is how many scenarios are solved, 0 means all of them
+func runBatch(scenFile string, names []string, limit int, opts Options) error {
+	scenarios, err := search.LoadScenarios(scenFile)
+	if err != nil {
+		return err
+	}
+	if limit > 0 && limit < len(scenarios) {
+		scenarios = scenarios[:limit]
+	}
+
+	if len(names) == 1 && names[0] == "all" {
+		names = nil
+		for _, a := range search.Algorithms() {
+			if !treeSearches[a.SearchType] {
+				names = append(names, a.Name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("no algorithms to run the scenarios with")
+	}
+	for _, name := range names {
+		if _, ok := search.Lookup(name); !ok {
+			return fmt.Errorf("unknown algorithm %q", name)
+		}
+	}
+
+	// the runs share the walls of a map, nothing may flip them
+	opts.Diagonal = true
+	opts.Corners = "forbid"
+	opts.Toggles = ""
+
+	// every map is read once, each run gets a copy with the scenario's ends
+	maps := make(map[string]*search.Maze)
+	tallies := make([]tally, len(names))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tbucket\tmap\talgorithm\tcost\toptimal\texpanded\ttime\tresult")
+
+	for i, s := range scenarios {
+		base, ok := maps[s.Map]
+		if !ok {
+			base, err = loadBenchmarkMap(scenFile, s.Map, opts)
+			if err != nil {
+				return err
+			}
+			maps[s.Map] = base
+		}
+
+		for j, name := range names {
+			m := *base
+			t := &tallies[j]
+
+			result, cost := "", "-"
+			startTime := time.Now()
+
+			if err := m.UseScenario(s); err != nil {
+				result = err.Error()
+			} else if err := search.Solve(name, &m); err != nil {
+				result = err.Error()
+			}
+
+			timeTaken := time.Since(startTime)
+			t.time += timeTaken
+			t.explored += m.NumExplored
+
+			switch {
+			case result != "":
+				t.failed++
+			case len(m.Solution.Cells) == 0 && !m.IsGoal(m.Start):
+				result = "no path"
+				t.failed++
+			case s.Matches(m.Solution.Cost):
+				result = "✓"
+				t.solved++
+				t.optimal++
+			default:
+				result = "✗"
+				t.solved++
+			}
+			if result == "✓" || result == "✗" {
+				// a scenario that starts on its goal is solved by the empty path
+				cost = fmt.Sprintf("%.4f", m.Solution.Cost)
+			}
+
+			fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\t%.4f\t%d\t%v\t%s\n", i+1, s.Bucket, s.Map, name, cost, s.Optimal, m.NumExplored, timeTaken, result)
+		}
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "algorithm\tsolved\toptimal\tfailed\texpanded\ttime\tmean time")
+	for j, name := range names {
+		t := tallies[j]
+		fmt.Fprintf(w, "%s\t%d/%d\t%d\t%d\t%d\t%v\t%v\n", name, t.solved, len(scenarios), t.optimal, t.failed, t.explored, t.time, t.time/time.Duration(len(scenarios)))
+	}
+
+	return w.Flush()
+}
+
+// loadBenchmarkMap reads the map a scenario names and sets the options on it.
+// the name is relative to the scenario file, the map can also be right next
+// to it when the name has the directories of the benchmark
+func loadBenchmarkMap(scenFile, name string, opts Options) (*search.Maze, error) {
+	dir := filepath.Dir(scenFile)
+
+	file := filepath.Join(dir, name)
+	if _, err := os.Stat(file); err != nil {
+		file = filepath.Join(dir, filepath.Base(name))
+	}
+
+	m, err := search.LoadMaze(file)
+	if err != nil {
+		return nil, err
+	}
+
+	// the scenarios pick the start and the goal of every run
+	opts.From, opts.To = "", ""
+	if err := opts.apply(m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// algorithmList splits the comma separated names of the -algorithms flag
+func algorithmList(s string) []string {
+	var names []string
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
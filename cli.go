@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tanvir-rifat007/graph-ai-search/render"
+	"github.com/tanvir-rifat007/graph-ai-search/search"
+)
+
+// runCLI solves one maze and prints the result in the terminal, the regions
+// of the starts are drawn into regionsFile when it is set
+func runCLI(mazeFile, algorithm, regionsFile string, opts Options) error {
+	m, err := search.LoadMaze(mazeFile)
+	if err != nil {
+		return err
+	}
+
+	if err := opts.apply(m); err != nil {
+		return err
+	}
+
+	fmt.Printf("Starting to solve maze using %s...\n", algorithm)
+
+	startTime := time.Now()
+
+	if err := search.Solve(algorithm, m); err != nil {
+		return err
+	}
+
+	timeTaken := time.Since(startTime)
+
+	if len(m.Solution.Actions) == 0 {
+		if m.Label != "" {
+			return fmt.Errorf("no solution found for %s (%s)", mazeFile, m.Label)
+		}
+		return fmt.Errorf("no solution found for %s", mazeFile)
+	}
+
+	m.PrintMaze()
+
+	fmt.Printf("📊 Solution steps: %d\n", len(m.Solution.Cells))
+	fmt.Printf("🔍 Nodes explored: %d\n", m.NumExplored)
+	fmt.Printf("💰 Path cost: %s\n", formatCost(m.Solution.Cost))
+	if m.Diagonal {
+		fmt.Printf("↗️  Diagonal moves: %d\n", m.Solution.DiagonalMoves())
+	}
+	if n := m.Solution.Teleports(); n > 0 {
+		fmt.Printf("🌀 Teleports: %d\n", n)
+	}
+	if m.Floors != nil {
+		fmt.Printf("🪜 Floors: %d, the path takes the stairs %d times\n", m.Levels(), m.Solution.LevelChanges())
+	}
+	for i, plan := range m.Plans {
+		fmt.Printf("🤖 Agent %d: cost %s, %d time steps, %d waits\n", i+1, formatCost(plan.Cost), len(plan.Cells), plan.Waits())
+	}
+	if m.Plans != nil {
+		fmt.Printf("💥 Conflicts of the agents' own paths: %d, %d constraint nodes expanded, makespan %d\n", m.Conflicts, m.ConstraintNodes, m.Makespan())
+	}
+	for i, p := range m.Paths {
+		fmt.Printf("🛣️  Path %d: %d steps, cost %s\n", i+1, len(p.Cells), formatCost(p.Cost))
+	}
+	if len(m.Obstacles) > 0 {
+		period, _ := m.ObstaclePeriod()
+		fmt.Printf("💂 Guards: %d, back where they started every %d steps, the path waits %d times\n", len(m.Obstacles), period, m.Solution.Waits())
+	}
+	if len(m.Starts) > 1 && m.Plans == nil {
+		fmt.Printf("🚪 Path starts at start %d: %d,%d\n", m.StartIndex(m.Start)+1, m.Start.X, m.Start.Y)
+	}
+	for i, n := range m.RegionSizes() {
+		fmt.Printf("🗺️  Start %d: closest start of %d cells\n", i+1, n)
+	}
+	if len(m.Goals) > 1 && m.Tour == nil && m.Plans == nil {
+		goal := m.Solution.Cells[len(m.Solution.Cells)-1]
+		fmt.Printf("🎯 Closest goal reached: %d,%d\n", goal.X, goal.Y)
+	}
+	for i, leg := range m.Tour {
+		fmt.Printf("🎯 Leg %d: %d,%d to %d,%d, cost %s, %d steps, %d nodes expanded\n", i+1, leg.From.X, leg.From.Y, leg.To.X, leg.To.Y, formatCost(leg.Cost), leg.Steps, leg.Explored)
+	}
+	for _, p := range m.Solution.Pickups() {
+		fmt.Printf("🔑 Step %d: picked up key %s\n", p.Step, p.Keys)
+	}
+	switch m.SearchType {
+	case search.IDDFS:
+		fmt.Printf("📏 Depth limit reached: %d after %d iterations\n", m.DepthLimit, m.Iterations)
+	case search.IDASTAR:
+		fmt.Printf("🔁 Threshold iterations: %d, final threshold %g\n", m.Iterations, m.Threshold)
+		fmt.Printf("♻️  Re-expansions: %d\n", m.Reexpanded)
+	case search.LPASTAR, search.DSTARLITE:
+		for i, n := range m.Replans {
+			if i == 0 {
+				fmt.Printf("🧱 First plan: %d cells expanded\n", n)
+			} else {
+				fmt.Printf("🧱 Replan %d: %d cells expanded\n", i, n)
+			}
+		}
+	case search.ARASTAR:
+		for i, p := range m.Phases {
+			fmt.Printf("⚖️  Solution %d: w=%g, cost %g, bound %.2f, %d nodes expanded\n", i+1, p.Weight, p.Solution.Cost, p.Bound, p.Explored)
+		}
+	}
+	fmt.Printf("⏱️  Time taken: %v\n", timeTaken)
+
+	if regionsFile != "" {
+		if m.Regions == nil {
+			return fmt.Errorf("%s has no regions to draw, it needs more than one start and no doors", mazeFile)
+		}
+		return render.OutputRegions(m, regionsFile)
+	}
+
+	return nil
+}
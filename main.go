@@ -1,35 +1,97 @@
 package main
 
 import (
+	"bytes"
 	"encoding/base64"
+	"flag"
 	"fmt"
 	"html/template"
-	"io/ioutil"
 	"log"
+	"math"
 	"net/http"
 	"os"
 	"strconv"
 	"time"
+
+	"github.com/tanvir-rifat007/graph-ai-search/render"
+	"github.com/tanvir-rifat007/graph-ai-search/search"
 )
 
+// animation frames are written here while a maze is being solved
+const framesDir = "./tmp"
+
 func init() {
-	_ = os.Mkdir("./tmp", os.ModePerm)
-	emptyTmp()
+	_ = os.Mkdir(framesDir, os.ModePerm)
+	render.ClearFrames(framesDir)
 }
 
 // Page data structure
 type PageData struct {
-	Algorithm     string
-	ImageData     string
-	AnimationData string
-	IsGenerated   bool
-	SolutionSteps int
-	NodesExplored int
-	TimeTaken     string
-	Width         int
-	Height        int
-	HasAnimation  bool
-	MazeType      string
+	Algorithm          string
+	ImageData          string
+	AnimationData      string
+	IsGenerated        bool
+	SolutionSteps      int
+	NodesExplored      int
+	PathCost           float64
+	TimeTaken          string
+	Width              int
+	Height             int
+	HasAnimation       bool
+	MazeType           string
+	Reopened           int
+	DepthReached       int
+	Iterations         int
+	CellsScanned       int
+	Reexpanded         int
+	Threshold          float64
+	Phases             []search.Phase
+	Replans            []int
+	DiagonalMoves      int
+	Pickups            []search.Pickup
+	Teleports          int
+	Tour               []search.Leg
+	ExactTour          bool
+	ClosestGoal        *search.Point
+	Starts             []search.Point
+	FromStart          int
+	RegionSizes        []int
+	RegionData         string
+	Plans              []search.Solution
+	Makespan           int
+	Conflicts          int
+	ConstraintNodes    int
+	MaxConstraintNodes int
+	Guards             int
+	GuardPeriod        int
+	Waits              int
+	Paths              []search.Solution
+	Nodes              int
+	Edges              int
+	Route              []string
+	Hex                bool
+	Levels             int
+	LevelChanges       int
+	StairCosts         []float64
+
+	// the options the maze was solved with, they fill the form again
+	Options
+}
+
+// HeuristicName is the heuristic the informed searches used, the one picked
+// in the form or the default one for the moves of the maze
+func (d PageData) HeuristicName() string {
+	switch {
+	case d.Nodes > 0:
+		return "zero (the nodes of a graph have no place to measure from)"
+	case d.Heuristic != "":
+		return d.Heuristic
+	case d.Hex:
+		return "hex"
+	case d.Diagonal:
+		return "octile"
+	}
+	return "manhattan"
 }
 
 // HTML template with animation support
@@ -294,21 +356,125 @@ const htmlTemplate = `
                     <select name="algorithm" id="algorithm" required>
                         <option value="">-- Choose Algorithm --</option>
                         <option value="DFS" {{if eq .Algorithm "DFS"}}selected{{end}}>DFS (Depth-First Search)</option>
+                        <option value="DLS" {{if eq .Algorithm "DLS"}}selected{{end}}>DLS (Depth-Limited Search)</option>
+                        <option value="IDDFS" {{if eq .Algorithm "IDDFS"}}selected{{end}}>IDDFS (Iterative Deepening DFS)</option>
                         <option value="BFS" {{if eq .Algorithm "BFS"}}selected{{end}}>BFS (Breadth-First Search)</option>
                         <option value="Dijkstra" {{if eq .Algorithm "Dijkstra"}}selected{{end}}>Dijkstra's Algorithm</option>
+                        <option value="GBFS" {{if eq .Algorithm "GBFS"}}selected{{end}}>GBFS (Greedy Best-First Search)</option>
                         <option value="AStar" {{if eq .Algorithm "AStar"}}selected{{end}}>A* Algorithm</option>
+                        <option value="JPS" {{if eq .Algorithm "JPS"}}selected{{end}}>JPS (Jump Point Search)</option>
+                        <option value="WAStar" {{if eq .Algorithm "WAStar"}}selected{{end}}>Weighted A*</option>
+                        <option value="ARAStar" {{if eq .Algorithm "ARAStar"}}selected{{end}}>ARA* (Anytime Repairing A*)</option>
+                        <option value="IDAStar" {{if eq .Algorithm "IDAStar"}}selected{{end}}>IDA* (Iterative Deepening A*)</option>
+                        <option value="LPAStar" {{if eq .Algorithm "LPAStar"}}selected{{end}}>LPA* (Lifelong Planning A*)</option>
+                        <option value="DStarLite" {{if eq .Algorithm "DStarLite"}}selected{{end}}>D* Lite (moving agent)</option>
+                        <option value="BiBFS" {{if eq .Algorithm "BiBFS"}}selected{{end}}>Bidirectional BFS</option>
+                        <option value="BiAStar" {{if eq .Algorithm "BiAStar"}}selected{{end}}>Bidirectional A*</option>
+                        <option value="CBS" {{if eq .Algorithm "CBS"}}selected{{end}}>Conflict-Based Search (several agents)</option>
+                        <option value="STAStar" {{if eq .Algorithm "STAStar"}}selected{{end}}>Space-Time A* (moving obstacles)</option>
+                        <option value="Yen" {{if eq .Algorithm "Yen"}}selected{{end}}>Yen's K Shortest Paths</option>
                     </select>
                 </div>
 
+                <div class="form-group">
+                    <label for="heuristic">🧭 Heuristic (A* and GBFS):</label>
+                    <select name="heuristic" id="heuristic">
+                        <option value="" {{if eq .Heuristic ""}}selected{{end}}>-- Default (Manhattan, Octile with diagonal moves, Hex on hex mazes) --</option>
+                        <option value="manhattan" {{if eq .Heuristic "manhattan"}}selected{{end}}>Manhattan</option>
+                        <option value="euclidean" {{if eq .Heuristic "euclidean"}}selected{{end}}>Euclidean</option>
+                        <option value="chebyshev" {{if eq .Heuristic "chebyshev"}}selected{{end}}>Chebyshev</option>
+                        <option value="octile" {{if eq .Heuristic "octile"}}selected{{end}}>Octile</option>
+                        <option value="hex" {{if eq .Heuristic "hex"}}selected{{end}}>Hex (hex mazes)</option>
+                        <option value="zero" {{if eq .Heuristic "zero"}}selected{{end}}>Zero (A* becomes Dijkstra)</option>
+                    </select>
+                </div>
+
+                <div class="form-group">
+                    <label for="depth">📏 Depth Limit (DLS, empty for no limit):</label>
+                    <input type="number" name="depth" id="depth" min="0" value="{{if .DepthLimit}}{{.DepthLimit}}{{end}}">
+                </div>
+
+                <div class="form-group">
+                    <label for="weight">⚖️ Heuristic Weight w (Weighted A*, starting w of ARA*):</label>
+                    <input type="number" name="weight" id="weight" min="1" step="0.1" value="{{if .Weight}}{{.Weight}}{{end}}" placeholder="default">
+                </div>
+
+                <div class="form-group">
+                    <label for="toggles">🧱 Wall Toggles (LPA* and D* Lite, after moves:row,col):</label>
+                    <input type="text" name="toggles" id="toggles" value="{{.Toggles}}" placeholder="e.g. 4:3,7 4:3,8 4:3,2 for maze-dynamic.txt">
+                </div>
+
+                <div class="form-group">
+                    <label for="portal">🌀 Portal Cost (cost of a teleport):</label>
+                    <input type="number" name="portal" id="portal" min="0" step="0.5" value="{{.PortalCost}}">
+                </div>
+
+                <div class="form-group">
+                    <label for="goals">🎯 Goals (mazes with more than one B):</label>
+                    <select name="goals" id="goals">
+                        <option value="any" {{if or (eq .Goals "") (eq .Goals "any")}}selected{{end}}>Reach any (the closest one)</option>
+                        <option value="all" {{if eq .Goals "all"}}selected{{end}}>Visit all (cheapest tour)</option>
+                    </select>
+                </div>
+
+                <div class="form-group">
+                    <label for="k">🛣️ K (Yen, how many of the shortest paths):</label>
+                    <input type="number" name="k" id="k" min="1" max="50" value="{{if .K}}{{.K}}{{end}}" placeholder="3 (default)">
+                </div>
+
+                <div class="form-group">
+                    <label for="from">🕸️ From / To (node names in graph files, row,col in mazes):</label>
+                    <input type="text" name="from" id="from" value="{{.From}}" placeholder="the file's start">
+                    <input type="text" name="to" id="to" value="{{.To}}" placeholder="the file's goal">
+                </div>
+
                 <div class="form-group">
                     <label for="maze">📁 Maze File:</label>
                     <select name="maze" id="maze" required>
                         <option value="">-- Choose MazeFile --</option>
                         <option value="maze.txt" {{if eq .MazeType "maze.txt"}}selected{{end}}>maze1.txt</option>
                         <option value="maze2.txt" {{if eq .MazeType "maze2.txt"}}selected{{end}}>maze2.txt</option>
+                        <option value="maze-weighted.txt" {{if eq .MazeType "maze-weighted.txt"}}selected{{end}}>maze-weighted.txt (terrain)</option>
+                        <option value="maze-dynamic.txt" {{if eq .MazeType "maze-dynamic.txt"}}selected{{end}}>maze-dynamic.txt (for wall toggles)</option>
+                        <option value="maze-keys.txt" {{if eq .MazeType "maze-keys.txt"}}selected{{end}}>maze-keys.txt (keys and doors)</option>
+                        <option value="maze-portals.txt" {{if eq .MazeType "maze-portals.txt"}}selected{{end}}>maze-portals.txt (portals)</option>
+                        <option value="maze-oneway.txt" {{if eq .MazeType "maze-oneway.txt"}}selected{{end}}>maze-oneway.txt (one-way aisles)</option>
+                        <option value="maze-goals.txt" {{if eq .MazeType "maze-goals.txt"}}selected{{end}}>maze-goals.txt (several goals)</option>
+                        <option value="maze-starts.txt" {{if eq .MazeType "maze-starts.txt"}}selected{{end}}>maze-starts.txt (several starts, BFS or Dijkstra)</option>
+                        <option value="maze-agents.txt" {{if eq .MazeType "maze-agents.txt"}}selected{{end}}>maze-agents.txt (several agents, CBS)</option>
+                        <option value="maze-guards.txt" {{if eq .MazeType "maze-guards.txt"}}selected{{end}}>maze-guards.txt (moving guards, Space-Time A*)</option>
+                        <option value="maze-routes.txt" {{if eq .MazeType "maze-routes.txt"}}selected{{end}}>maze-routes.txt (several routes, Yen)</option>
+                        <option value="maze-hex.txt" {{if eq .MazeType "maze-hex.txt"}}selected{{end}}>maze-hex.txt (hex cells, six moves)</option>
+                        <option value="maze-floors.txt" {{if eq .MazeType "maze-floors.txt"}}selected{{end}}>maze-floors.txt (three floors with stairs)</option>
+                        <option value="graph-roads.gr" {{if eq .MazeType "graph-roads.gr"}}selected{{end}}>graph-roads.gr (DIMACS road graph)</option>
+                        <option value="graph-network.dot" {{if eq .MazeType "graph-network.dot"}}selected{{end}}>graph-network.dot (Graphviz DOT network)</option>
+                        <option value="graph-metro.json" {{if eq .MazeType "graph-metro.json"}}selected{{end}}>graph-metro.json (JSON adjacency list)</option>
                     </select>
                 </div>
 
+                <div class="form-group">
+                    <label>
+                        <input type="checkbox" name="diagonal" value="1" {{if .Diagonal}}checked{{end}}>
+                        ↗️ Diagonal moves (8-connected, √2 per diagonal move)
+                    </label>
+                </div>
+
+                <div class="form-group">
+                    <label for="corners">📐 Corner Cutting (diagonal moves):</label>
+                    <select name="corners" id="corners">
+                        <option value="allow" {{if or (eq .Corners "") (eq .Corners "allow")}}selected{{end}}>Allow</option>
+                        <option value="no-squeeze" {{if eq .Corners "no-squeeze"}}selected{{end}}>Forbid through wall corners (between two walls)</option>
+                        <option value="forbid" {{if eq .Corners "forbid"}}selected{{end}}>Forbid entirely (no wall next to the move)</option>
+                    </select>
+                </div>
+
+                <div class="form-group">
+                    <label>
+                        <input type="checkbox" name="reopen" value="1" {{if .Reopen}}checked{{end}}>
+                        ♻️ Reopen closed nodes (for inconsistent heuristics)
+                    </label>
+                </div>
+
                 <button type="submit">⚡ Generate Maze Animation</button>
             </div>
         </form>
@@ -330,14 +496,113 @@ const htmlTemplate = `
                     <div class="stat-label">🔍 Nodes Explored</div>
                     <div class="stat-value">{{.NodesExplored}}</div>
                 </div>
+                {{if or (eq .Algorithm "DLS") (eq .Algorithm "IDDFS")}}
+                <div class="stat-item">
+                    <div class="stat-label">📏 Depth Limit Reached</div>
+                    <div class="stat-value">{{.DepthReached}}</div>
+                </div>
+                {{end}}
+                {{if or (eq .Algorithm "LPAStar") (eq .Algorithm "DStarLite")}}
+                <div class="stat-item">
+                    <div class="stat-label">🧱 Replans</div>
+                    <div class="stat-value">{{len (slice .Replans 1)}}</div>
+                </div>
+                {{end}}
+                {{if .Plans}}
+                <div class="stat-item">
+                    <div class="stat-label">🤖 Agents</div>
+                    <div class="stat-value">{{len .Plans}}</div>
+                </div>
+                <div class="stat-item">
+                    <div class="stat-label">⏳ Makespan</div>
+                    <div class="stat-value">{{.Makespan}}</div>
+                </div>
+                <div class="stat-item">
+                    <div class="stat-label">💥 Conflicts Before CBS</div>
+                    <div class="stat-value">{{.Conflicts}}</div>
+                </div>
+                {{end}}
+                {{if .Paths}}
+                <div class="stat-item">
+                    <div class="stat-label">🛣️ Paths Found</div>
+                    <div class="stat-value">{{len .Paths}}</div>
+                </div>
+                {{end}}
+                {{if .Guards}}
+                <div class="stat-item">
+                    <div class="stat-label">💂 Guards</div>
+                    <div class="stat-value">{{.Guards}}</div>
+                </div>
+                <div class="stat-item">
+                    <div class="stat-label">⏸️ Waits</div>
+                    <div class="stat-value">{{.Waits}}</div>
+                </div>
+                {{end}}
+                {{if eq .Algorithm "IDAStar"}}
+                <div class="stat-item">
+                    <div class="stat-label">🔁 Threshold Iterations</div>
+                    <div class="stat-value">{{.Iterations}}</div>
+                </div>
+                <div class="stat-item">
+                    <div class="stat-label">♻️ Re-expansions</div>
+                    <div class="stat-value">{{.Reexpanded}}</div>
+                </div>
+                {{end}}
+                {{if .Starts}}
+                <div class="stat-item">
+                    <div class="stat-label">🚪 Starts</div>
+                    <div class="stat-value">{{len .Starts}}</div>
+                </div>
+                {{end}}
+                {{if .Tour}}
+                <div class="stat-item">
+                    <div class="stat-label">🎯 Goals Visited</div>
+                    <div class="stat-value">{{len .Tour}}</div>
+                </div>
+                {{end}}
+                {{if gt .Levels 1}}
+                <div class="stat-item">
+                    <div class="stat-label">🪜 Stairs Taken</div>
+                    <div class="stat-value">{{.LevelChanges}}</div>
+                </div>
+                {{end}}
+                {{if .Teleports}}
+                <div class="stat-item">
+                    <div class="stat-label">🌀 Teleports</div>
+                    <div class="stat-value">{{.Teleports}}</div>
+                </div>
+                {{end}}
+                {{if .Pickups}}
+                <div class="stat-item">
+                    <div class="stat-label">🔑 Keys Picked Up</div>
+                    <div class="stat-value">{{len .Pickups}}</div>
+                </div>
+                {{end}}
+                {{if .Diagonal}}
+                <div class="stat-item">
+                    <div class="stat-label">↗️ Diagonal Moves</div>
+                    <div class="stat-value">{{.DiagonalMoves}}</div>
+                </div>
+                {{end}}
+                <div class="stat-item">
+                    <div class="stat-label">💰 Path Cost</div>
+                    <div class="stat-value">{{cost .PathCost}}</div>
+                </div>
                 <div class="stat-item">
                     <div class="stat-label">⏱️ Time Taken</div>
                     <div class="stat-value">{{.TimeTaken}}</div>
                 </div>
+                {{if .Nodes}}
+                <div class="stat-item">
+                    <div class="stat-label">🕸️ Graph Size</div>
+                    <div class="stat-value">{{.Nodes}} nodes, {{.Edges}} edges</div>
+                </div>
+                {{else}}
                 <div class="stat-item">
                     <div class="stat-label">📐 Maze Size</div>
                     <div class="stat-value">{{.Width}}×{{.Height}}</div>
                 </div>
+                {{end}}
             </div>
 
             {{if .HasAnimation}}
@@ -355,6 +620,13 @@ const htmlTemplate = `
                 <img id="staticImg" src="data:image/png;base64,{{.ImageData}}" 
                      alt="Final Maze" class="maze-image {{if not .HasAnimation}}active{{end}}">
             </div>
+
+            {{if .RegionData}}
+            <div class="maze-display">
+                <h3>🗺️ Closest Start of Every Cell:</h3>
+                <img src="data:image/png;base64,{{.RegionData}}" alt="Start Regions" class="maze-image active">
+            </div>
+            {{end}}
             
             <div class="info-box">
                 <h3>Algorithm Info:</h3>
@@ -364,6 +636,17 @@ const htmlTemplate = `
                     <li>Strategy: Explores deep into paths before backtracking</li>
                     <li>Complete: Yes (for finite graphs)</li>
                     <li>Optimal: No</li>
+                    {{else if eq .Algorithm "DLS"}}
+                    <li>Type: Uninformed Search</li>
+                    <li>Strategy: Depth-first, but never deeper than the depth limit</li>
+                    <li>Complete: No (the goal may be beyond the limit)</li>
+                    <li>Optimal: No</li>
+                    {{else if eq .Algorithm "IDDFS"}}
+                    <li>Type: Uninformed Search</li>
+                    <li>Strategy: Repeats depth-limited search with limit 0, 1, 2, ... until the goal is found</li>
+                    <li>Complete: Yes</li>
+                    <li>Optimal: Yes (fewest moves, for unweighted graphs)</li>
+                    <li>Iterations: {{.Iterations}}</li>
                     {{else if eq .Algorithm "BFS"}}
                     <li>Type: Uninformed Search</li>
                     <li>Strategy: Explores level by level</li>
@@ -371,15 +654,118 @@ const htmlTemplate = `
                     <li>Optimal: Yes (for unweighted graphs)</li>
                     {{else if eq .Algorithm "Dijkstra"}}
                     <li>Type: Uninformed Search</li>
-                    <li>Strategy: Explores by shortest distance from start</li>
+                    <li>Strategy: Explores by cheapest path cost from start (terrain weights count)</li>
                     <li>Complete: Yes</li>
                     <li>Optimal: Yes</li>
+                    {{else if eq .Algorithm "GBFS"}}
+                    <li>Type: Informed Search (Heuristic)</li>
+                    <li>Strategy: Always expands the node that looks closest to the goal (lowest h)</li>
+                    <li>Complete: Yes (for finite graphs)</li>
+                    <li>Optimal: No</li>
+                    <li>Heuristic: {{.HeuristicName}}</li>
                     {{else if eq .Algorithm "AStar"}}
                     <li>Type: Informed Search (Heuristic)</li>
-                    <li>Strategy: Uses heuristic to guide search toward goal</li>
+                    <li>Strategy: Expands the lowest f = g + h, g is the real path cost and h the heuristic</li>
+                    <li>Complete: Yes</li>
+                    <li>Optimal: Yes (with a consistent heuristic, or an admissible one with reopening)</li>
+                    <li>Heuristic: {{.HeuristicName}}</li>
+                    {{if .Reopen}}<li>Closed nodes reopened: {{.Reopened}}</li>{{end}}
+                    {{else if eq .Algorithm "JPS"}}
+                    <li>Type: Informed Search (Heuristic)</li>
+                    <li>Strategy: A* that jumps along straight lines and only expands jump points</li>
+                    <li>Complete: Yes</li>
+                    <li>Optimal: Yes (only solves uniform-cost grids, mazes with weighted terrain are rejected)</li>
+                    <li>Heuristic: {{.HeuristicName}}</li>
+                    <li>Jump points expanded: {{.NodesExplored}}, cells scanned: {{.CellsScanned}}</li>
+                    <li>Colors: orange jump points, purple cells the jumps went over</li>
+                    {{else if eq .Algorithm "WAStar"}}
+                    <li>Type: Informed Search (Heuristic)</li>
+                    <li>Strategy: A* that expands the lowest f = g + w·h, a bigger w heads for the goal faster</li>
+                    <li>Complete: Yes</li>
+                    <li>Optimal: No, the path costs at most w times the optimal one (with an admissible heuristic)</li>
+                    <li>Heuristic: {{.HeuristicName}}, w = {{if .Weight}}{{.Weight}}{{else}}2 (default){{end}}</li>
+                    {{else if eq .Algorithm "ARAStar"}}
+                    <li>Type: Informed Search (Heuristic), anytime</li>
+                    <li>Strategy: Weighted A* with a big w for a quick first path, then lowers w and repairs the search to improve it</li>
+                    <li>Complete: Yes</li>
+                    <li>Optimal: Yes at the end (bound 1), every path before that is within its bound of the optimal one</li>
+                    <li>Heuristic: {{.HeuristicName}}, starting w = {{if .Weight}}{{.Weight}}{{else}}3 (default){{end}}</li>
+                    {{range .Phases}}
+                    <li>Path found with w = {{.Weight}}: cost {{cost .Solution.Cost}}, within {{printf "%.2f" .Bound}}× optimal, {{.Explored}} nodes expanded</li>
+                    {{end}}
+                    {{else if eq .Algorithm "IDAStar"}}
+                    <li>Type: Informed Search (Heuristic)</li>
+                    <li>Strategy: Depth-first passes bounded by f = g + h, the bound grows to the smallest f that went over it</li>
                     <li>Complete: Yes</li>
                     <li>Optimal: Yes (with admissible heuristic)</li>
+                    <li>Memory: only the current path</li>
+                    <li>Heuristic: {{.HeuristicName}}</li>
+                    <li>Final threshold: {{.Threshold}}</li>
+                    {{else if eq .Algorithm "LPAStar"}}
+                    <li>Type: Informed Search (Heuristic), incremental</li>
+                    <li>Strategy: A* that keeps its distances, after the walls change it only expands the cells whose distance changed</li>
+                    <li>Complete: Yes</li>
+                    <li>Optimal: Yes (with a consistent heuristic), after every change</li>
+                    <li>Heuristic: {{.HeuristicName}}</li>
+                    {{range $i, $n := .Replans}}<li>{{if $i}}Replan {{$i}}{{else}}First plan{{end}}: {{$n}} cells expanded</li>{{end}}
+                    <li>Colors: red walls that just changed, purple cells the last plan expanded</li>
+                    {{else if eq .Algorithm "DStarLite"}}
+                    <li>Type: Informed Search (Heuristic), incremental</li>
+                    <li>Strategy: Searches backwards from the goal, the agent walks the plan and it is repaired where the agent stands when walls change</li>
+                    <li>Complete: Yes</li>
+                    <li>Optimal: Every plan is the cheapest way from the agent on the map it knows</li>
+                    <li>Heuristic: {{.HeuristicName}}</li>
+                    {{range $i, $n := .Replans}}<li>{{if $i}}Replan {{$i}}{{else}}First plan{{end}}: {{$n}} cells expanded</li>{{end}}
+                    <li>Colors: white agent, teal cells it walked, red walls that just changed</li>
+                    {{else if eq .Algorithm "CBS"}}
+                    <li>Type: Multi-agent path finding</li>
+                    <li>Strategy: Every agent on its own shortest path, then the first conflict (same cell at the same time, or two agents swapping cells) is split into two constraint nodes, one for each agent, and that agent gets a new plan from a space-time A*</li>
+                    <li>Complete: Yes, up to {{.MaxConstraintNodes}} constraint nodes</li>
+                    <li>Optimal: Yes, the sum of the costs of the plans is the smallest (a wait costs 1)</li>
+                    <li>The agents' own shortest paths had {{.Conflicts}} conflicts, {{.ConstraintNodes}} constraint nodes expanded</li>
+                    {{range $i, $p := .Plans}}<li>Agent {{inc $i}}: cost {{cost $p.Cost}}, {{len $p.Cells}} time steps, {{$p.Waits}} waits</li>{{end}}
+                    <li>Colors: every agent has its own line from A to B, the animation moves all of them one time step at a time</li>
+                    {{else if eq .Algorithm "STAStar"}}
+                    <li>Type: Informed Search (Heuristic), in space and time</li>
+                    <li>Strategy: A* over the cells at every time step, the agent can also wait in its cell for a step to let a guard walk by</li>
+                    <li>Complete: Yes, the guards are all back where they started every {{.GuardPeriod}} steps so that's all the time steps it has to tell apart</li>
+                    <li>Optimal: Yes (with an admissible heuristic), a wait costs 1</li>
+                    <li>Heuristic: {{.HeuristicName}}</li>
+                    <li>{{.SolutionSteps}} time steps, {{.Waits}} of them waiting</li>
+                    <li>Colors: orange guards G1, G2… with their routes, the white agent on its cyan path, the animation is one frame per time step</li>
+                    {{else if eq .Algorithm "Yen"}}
+                    <li>Type: Uninformed Search, K shortest paths</li>
+                    <li>Strategy: Dijkstra for the cheapest path, then every next path leaves an earlier one at one of its cells (the spur) without walking back into the cells before it or taking a move an earlier path took from there, the cheapest of those is the next path</li>
+                    <li>Complete: Yes, it finds K paths or every loopless path there is</li>
+                    <li>Optimal: Yes, the paths come from the cheapest on and none of them visits a cell twice</li>
+                    {{range $i, $p := .Paths}}<li><span style="color: {{color $i}}">■</span> Path {{inc $i}}: {{len $p.Cells}} steps, cost {{cost $p.Cost}}</li>{{end}}
+                    <li>Colors: every path has its own line, the first one is also cyan, the animation shows one path per frame</li>
+                    {{else if eq .Algorithm "BiBFS"}}
+                    <li>Type: Uninformed Search</li>
+                    <li>Strategy: BFS from the start and from the goal, one layer at a time, until they meet</li>
+                    <li>Complete: Yes</li>
+                    <li>Optimal: Yes (for unweighted graphs)</li>
+                    <li>Colors: purple from the start, orange from the goal, yellow where they met</li>
+                    {{else if eq .Algorithm "BiAStar"}}
+                    <li>Type: Informed Search (Heuristic)</li>
+                    <li>Strategy: A* from both ends, stops when no frontier can beat the best meeting point</li>
+                    <li>Complete: Yes</li>
+                    <li>Optimal: Yes (with a consistent heuristic)</li>
+                    <li>Heuristic: {{.HeuristicName}}</li>
+                    <li>Colors: purple from the start, orange from the goal, yellow where they met</li>
                     {{end}}
+                    {{if .Route}}<li>Route: {{range $i, $n := .Route}}{{if $i}} → {{end}}{{$n}}{{end}}</li>{{end}}
+                    {{if .Nodes}}<li>Graph: the nodes are drawn where the file puts them, or spread out by a spring layout when it doesn't. Arrows are one-way edges, the numbers on the edges are their costs</li>{{end}}
+                    {{if .Starts}}<li>The path starts at start {{.FromStart}}</li>{{end}}
+                    {{range $i, $s := .Starts}}<li>Start {{inc $i}} at {{$s.X}},{{$s.Y}}{{if $.RegionSizes}}: closest start of {{index $.RegionSizes $i}} cells{{end}}</li>{{end}}
+                    {{if .RegionData}}<li>Regions: every start has its own color, white lines are the borders, d= is the cost from the closest start (the number of moves for BFS) and the yellow line is the path</li>{{end}}
+                    {{with .ClosestGoal}}<li>Closest goal reached: {{.X}},{{.Y}}</li>{{end}}
+                    {{if .Tour}}<li>Visiting order{{if .ExactTour}} (exact){{else}} (nearest neighbor and 2-opt, may not be the best){{end}}:</li>{{end}}
+                    {{range $i, $leg := .Tour}}<li>Leg {{inc $i}}: {{$leg.From.X}},{{$leg.From.Y}} → {{$leg.To.X}},{{$leg.To.Y}}, cost {{cost $leg.Cost}}, {{$leg.Steps}} steps, {{$leg.Explored}} nodes expanded</li>{{end}}
+                    {{range .Pickups}}<li>Step {{.Step}}: picked up key {{.Keys}}</li>{{end}}
+                    {{if .Pickups}}<li>Colors: gold keys, grey doors, k: on the path is the key set held there</li>{{end}}
+                    {{if .Teleports}}<li>Teleports: {{.Teleports}}, each one costs {{cost .PortalCost}}. Colors: ultraviolet portals, yellow lines for the jumps the path made</li>{{end}}
+                    {{if gt .Levels 1}}<li>Floors: {{.Levels}}, drawn from left to right. Stairs cost{{range $i, $c := .StairCosts}}{{if $i}},{{end}} {{cost $c}} between floor {{$i}} and {{inc $i}}{{end}}. Colors: jade stairs, yellow lines and boxes where the path changes floor</li>{{end}}
                 </ul>
             </div>
         </div>
@@ -416,12 +802,42 @@ const htmlTemplate = `
 `
 
 func main() {
-	tmpl := template.Must(template.New("index").Parse(htmlTemplate))
+	port := flag.String("addr", ":8080", "address the web server listens on")
+	mazeFile := flag.String("maze", "", "solve this maze file in the terminal instead of starting the web server")
+	algorithm := flag.String("algorithm", "AStar", "algorithm used with -maze")
+	regions := flag.String("regions", "", "with -maze, draw the closest start of every cell into this png file (mazes with more than one start)")
+	scenFile := flag.String("scen", "", "solve every scenario of this Moving AI .scen file in the terminal and compare the costs with the optimal lengths")
+	batchAlgorithms := flag.String("algorithms", "AStar,Dijkstra,JPS,BiAStar", "comma separated algorithms used with -scen, all for every one but the tree searches DLS, IDDFS and IDAStar, they don't finish on a big map")
+	scenarios := flag.Int("scenarios", 0, "with -scen, only solve the first n scenarios, 0 means all of them")
+	var defaults Options
+	defaults.registerFlags(flag.CommandLine)
+	flag.Parse()
+
+	if _, err := search.LookupHeuristic(defaults.Heuristic); err != nil {
+		log.Fatal(err)
+	}
+
+	if *scenFile != "" {
+		if err := runBatch(*scenFile, algorithmList(*batchAlgorithms), *scenarios, defaults); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if *mazeFile != "" {
+		if err := runCLI(*mazeFile, *algorithm, *regions, defaults); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	tmpl := template.Must(template.New("index").Funcs(template.FuncMap{"cost": formatCost, "inc": func(i int) int { return i + 1 }, "color": render.PaletteHex}).Parse(htmlTemplate))
 
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == "GET" {
 			data := PageData{
 				IsGenerated: false,
+				Options:     defaults,
 			}
 			tmpl.Execute(w, data)
 			return
@@ -430,6 +846,7 @@ func main() {
 		if r.Method == "POST" {
 			algorithm := r.FormValue("algorithm")
 			mazeFile := r.FormValue("maze")
+			opts := formOptions(r, defaults)
 
 			fmt.Println("mazefile : ", mazeFile)
 			if mazeFile == "" {
@@ -438,54 +855,66 @@ func main() {
 
 			fmt.Printf("🎯 Generating maze with %s algorithm from %s...\n", algorithm, mazeFile)
 
-			var m Maze
-			m.Animate = true
-			err := m.loadMaze(mazeFile)
+			m, err := search.LoadMaze(mazeFile)
 			if err != nil {
 				http.Error(w, fmt.Sprintf("Error loading maze: %v", err), http.StatusInternalServerError)
 				return
 			}
 
-			fmt.Printf("📐 Maze dimensions: %d×%d\n", m.Height, m.Width-1)
+			if m.Network != nil {
+				fmt.Printf("🕸️  Graph: %d nodes, %d edges\n", m.Network.Len(), m.Network.NumEdges())
+			} else {
+				fmt.Printf("📐 Maze dimensions: %d×%d\n", m.Height, m.Width)
+			}
+			if m.Floors != nil {
+				fmt.Printf("🪜 Floors: %d\n", m.Levels())
+			}
+
+			render.ClearFrames(framesDir)
+			m.Animate = true
+			m.Frame = render.Frames(framesDir)
+			if err := opts.apply(m); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			fmt.Printf("Starting to solve maze using %s...\n", algorithm)
 
 			startTime := time.Now()
 
 			// Solve based on algorithm
-			switch algorithm {
-			case "DFS":
-				m.SearchType = DFS
-				solveDFS(&m)
-			case "BFS":
-				m.SearchType = BFS
-				solveBFS(&m)
-			case "Dijkstra":
-				m.SearchType = DIJKSTRA
-				solveDijkstra(&m)
-			case "AStar":
-				m.SearchType = ASTAR
-				solveAStar(&m)
-			default:
-				http.Error(w, "Invalid search type", http.StatusBadRequest)
+			if err := search.Solve(algorithm, m); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
 
 			timeTaken := time.Since(startTime)
 
 			if len(m.Solution.Actions) == 0 {
-				http.Error(w, "No solution found for this maze", http.StatusInternalServerError)
+				msg := "No solution found for this maze"
+				if m.Label != "" {
+					msg += " (" + m.Label + ")"
+				}
+				http.Error(w, msg, http.StatusInternalServerError)
 				return
 			}
 
 			fmt.Println("✅ Solution found!")
 			fmt.Printf("📊 Solution steps: %d\n", len(m.Solution.Cells))
-			fmt.Printf("🔍 Nodes explored: %d\n", len(m.Explored))
+			fmt.Printf("🔍 Nodes explored: %d\n", m.NumExplored)
+			fmt.Printf("💰 Path cost: %s\n", formatCost(m.Solution.Cost))
 			fmt.Printf("⏱️  Time taken: %v\n", timeTaken)
 
 			// Generate static image
-			m.OutputImage("image.png")
+			if err := render.OutputImage(m, "image.png"); err != nil {
+				http.Error(w, fmt.Sprintf("Error drawing maze: %v", err), http.StatusInternalServerError)
+				return
+			}
 
 			// Generate animation
-			m.OutputAnimatedImage()
+			if err := render.OutputAnimatedImage(framesDir, "image.png", "animation.png"); err != nil {
+				log.Println(err)
+			}
 
 			// Read both static and animation images
 			staticData := ""
@@ -493,7 +922,7 @@ func main() {
 			hasAnimation := false
 
 			// Read static image (always available)
-			if imgBytes, err := ioutil.ReadFile("./image.png"); err == nil {
+			if imgBytes, err := os.ReadFile("./image.png"); err == nil {
 				staticData = base64.StdEncoding.EncodeToString(imgBytes)
 			} else {
 				http.Error(w, "Error reading static image", http.StatusInternalServerError)
@@ -502,7 +931,7 @@ func main() {
 
 			// Read animation if it exists
 			if _, err := os.Stat("./animation.png"); err == nil {
-				if imgBytes, err := ioutil.ReadFile("./animation.png"); err == nil {
+				if imgBytes, err := os.ReadFile("./animation.png"); err == nil {
 					animationData = base64.StdEncoding.EncodeToString(imgBytes)
 					hasAnimation = true
 					fmt.Println("✅ Animation loaded successfully")
@@ -518,55 +947,103 @@ func main() {
 				AnimationData: animationData,
 				IsGenerated:   true,
 				SolutionSteps: len(m.Solution.Cells),
-				NodesExplored: len(m.Explored),
+				NodesExplored: m.NumExplored,
+				PathCost:      m.Solution.Cost,
 				TimeTaken:     timeTaken.String(),
 				Width:         m.Width,
 				Height:        m.Height,
 				HasAnimation:  hasAnimation,
 				MazeType:      mazeFile,
+				Options:       opts,
+				Reopened:      m.Reopened,
+				DepthReached:  m.DepthLimit,
+				Iterations:    m.Iterations,
+				CellsScanned:  len(m.Explored),
+				Reexpanded:    m.Reexpanded,
+				Threshold:     m.Threshold,
+				Phases:        m.Phases,
+				Replans:       m.Replans,
+				DiagonalMoves: m.Solution.DiagonalMoves(),
+				Pickups:       m.Solution.Pickups(),
+				Teleports:     m.Solution.Teleports(),
+				Tour:          m.Tour,
+				Paths:         m.Paths,
+				Route:         m.Route(),
+				ExactTour:     len(m.Goals) <= search.MaxExactGoals,
+				Hex:           m.Hex,
+				Levels:        m.Levels(),
+				LevelChanges:  m.Solution.LevelChanges(),
+				StairCosts:    m.StairCosts,
+			}
+
+			if len(m.Goals) > 1 && m.Tour == nil && m.Plans == nil {
+				goal := m.Solution.Cells[len(m.Solution.Cells)-1]
+				data.ClosestGoal = &goal
+			}
+
+			if m.Plans != nil {
+				data.Plans = m.Plans
+				data.Makespan = m.Makespan()
+				data.Conflicts = m.Conflicts
+				data.ConstraintNodes = m.ConstraintNodes
+				data.MaxConstraintNodes = search.MaxConstraintNodes
+			}
+
+			if m.Network != nil {
+				data.Nodes = m.Network.Len()
+				data.Edges = m.Network.NumEdges()
+			}
+
+			if len(m.Obstacles) > 0 {
+				data.Guards = len(m.Obstacles)
+				data.GuardPeriod, _ = m.ObstaclePeriod()
+				data.Waits = m.Solution.Waits()
+			}
+
+			if len(m.Starts) > 1 && m.Plans == nil {
+				data.Starts = m.Starts
+				data.FromStart = m.StartIndex(m.Start) + 1
+			}
+
+			if m.Regions != nil {
+				data.RegionSizes = m.RegionSizes()
+
+				var buf bytes.Buffer
+				if err := render.WriteRegions(&buf, m); err != nil {
+					log.Println(err)
+				} else {
+					data.RegionData = base64.StdEncoding.EncodeToString(buf.Bytes())
+				}
 			}
 			tmpl.Execute(w, data)
 			return
 		}
 	})
 
-	port := ":8080"
-	fmt.Printf("🚀 Maze Visualizer starting on http://localhost%s\n", port)
+	fmt.Printf("🚀 Maze Visualizer starting on http://localhost%s\n", *port)
 	fmt.Println("✨ theme activated!")
 	fmt.Println("📁 Make sure your maze.txt file is in the same directory")
-	log.Fatal(http.ListenAndServe(port, nil))
-}
-
-func solveDFS(m *Maze) {
-	var s DepthFirstSearch
-	s.Game = m
-	fmt.Println("🎯 Goal is:", s.Game.Goal)
-	s.Solve()
+	log.Fatal(http.ListenAndServe(*port, nil))
 }
 
-func solveBFS(m *Maze) {
-	var s BreadthFirstSearch
-	s.Game = m
-	s.Solve()
-	fmt.Println("🔄 BFS solver called")
-}
-
-func solveDijkstra(m *Maze) {
-	var s DijkstraSearch
-	s.Game = m
-	s.Solve()
-	fmt.Println("📊 Dijkstra solver called")
+func atoi(s string, defaultValue int) int {
+	if val, err := strconv.Atoi(s); err == nil {
+		return val
+	}
+	return defaultValue
 }
 
-func solveAStar(m *Maze) {
-	var s AstrSearch
-	s.Game = m
-	s.Solve()
-	fmt.Println("⭐ A* solver called")
+// formatCost drops the decimals of whole costs, the others (with diagonal
+// moves) get two
+func formatCost(cost float64) string {
+	if cost == math.Trunc(cost) {
+		return strconv.FormatFloat(cost, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(cost, 'f', 2, 64)
 }
 
-func atoi(s string, defaultValue int) int {
-	if val, err := strconv.Atoi(s); err == nil {
+func atof(s string, defaultValue float64) float64 {
+	if val, err := strconv.ParseFloat(s, 64); err == nil {
 		return val
 	}
 	return defaultValue
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/tanvir-rifat007/graph-ai-search/search"
+)
+
+// Options are the settings of one run, the command line flags set the
+// defaults and the web form can change them for each request
+type Options struct {
+	Heuristic  string
+	Reopen     bool
+	DepthLimit int
+	Weight     float64
+	Toggles    string
+	Diagonal   bool
+	Corners    string
+	PortalCost float64
+	Goals      string
+	K          int
+	From       string
+	To         string
+}
+
+// registerFlags binds the options to their command line flags
+func (o *Options) registerFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.Heuristic, "heuristic", "", "heuristic for A* and GBFS (manhattan, euclidean, chebyshev, octile, hex, zero), octile is the default with -diagonal and hex in a hex maze")
+	fs.BoolVar(&o.Reopen, "reopen", false, "reopen closed nodes when a cheaper way to them is found")
+	fs.IntVar(&o.DepthLimit, "depth", 0, "depth limit for DLS, 0 means no limit")
+	fs.Float64Var(&o.Weight, "weight", 0, "heuristic weight w for weighted A* and the starting w of ARA*, 0 means the default")
+	fs.BoolVar(&o.Diagonal, "diagonal", false, "allow diagonal moves, each one costs √2 times the cell it goes into")
+	fs.StringVar(&o.Corners, "corners", "", "when diagonal moves may cut a wall corner (allow, no-squeeze, forbid)")
+	fs.StringVar(&o.Toggles, "toggles", "", "walls LPA* and D* Lite flip while running, like \"4:3,7 4:3,8\" (after moves:row,col)")
+	fs.Float64Var(&o.PortalCost, "portal-cost", search.DefaultPortalCost, "cost of going through a portal")
+	fs.StringVar(&o.Goals, "goals", "", "what to do with more than one goal (any: reach the closest, all: visit every one)")
+	fs.IntVar(&o.K, "k", 0, "how many of the shortest paths Yen's algorithm finds, 0 means the default")
+	fs.StringVar(&o.From, "from", "", "where to start, the name of a node in a graph file or row,col in a maze, the file's start when empty")
+	fs.StringVar(&o.To, "to", "", "where to go, the name of a node in a graph file or row,col in a maze, the file's goal when empty")
+}
+
+// formOptions reads the options from the submitted form, the fields that
+// were left empty keep the defaults
+func formOptions(r *http.Request, defaults Options) Options {
+	o := defaults
+
+	if h := r.FormValue("heuristic"); h != "" {
+		o.Heuristic = h
+	}
+	o.Reopen = r.FormValue("reopen") != ""
+	o.DepthLimit = atoi(r.FormValue("depth"), defaults.DepthLimit)
+	o.Weight = atof(r.FormValue("weight"), defaults.Weight)
+	o.Toggles = strings.TrimSpace(r.FormValue("toggles"))
+	o.Diagonal = r.FormValue("diagonal") != ""
+	if c := r.FormValue("corners"); c != "" {
+		o.Corners = c
+	}
+	o.PortalCost = atof(r.FormValue("portal"), defaults.PortalCost)
+	if g := r.FormValue("goals"); g != "" {
+		o.Goals = g
+	}
+	o.K = atoi(r.FormValue("k"), defaults.K)
+	o.From = strings.TrimSpace(r.FormValue("from"))
+	o.To = strings.TrimSpace(r.FormValue("to"))
+
+	return o
+}
+
+// apply copies the options onto the maze before it is solved
+func (o Options) apply(m *search.Maze) error {
+	// the nodes of a graph file have no place on a grid
+	if m.Network != nil {
+		if o.Heuristic != "" && o.Heuristic != "zero" {
+			return fmt.Errorf("the %s heuristic needs a grid maze, a graph only has the zero heuristic", o.Heuristic)
+		}
+		if o.Diagonal {
+			return fmt.Errorf("diagonal moves need a grid maze")
+		}
+	}
+
+	// a hex has six moves, none of them is a diagonal one
+	if m.Hex && o.Diagonal {
+		return fmt.Errorf("diagonal moves need a square maze, a hex maze has its six moves already")
+	}
+
+	// without a heuristic the maze uses the default one for its moves
+	if o.Heuristic != "" {
+		h, err := search.LookupHeuristic(o.Heuristic)
+		if err != nil {
+			return err
+		}
+
+		m.Heuristic = h
+	}
+
+	m.Reopen = o.Reopen
+	m.DepthLimit = o.DepthLimit
+	m.Weight = o.Weight
+	m.Diagonal = o.Diagonal
+
+	if o.K < 0 || o.K > search.MaxK {
+		return fmt.Errorf("k %d must be between 0 and %d", o.K, search.MaxK)
+	}
+	m.K = o.K
+
+	if o.PortalCost < 0 {
+		return fmt.Errorf("portal cost %g can't be negative", o.PortalCost)
+	}
+	m.PortalCost = o.PortalCost
+
+	var err error
+
+	m.Corners, err = search.LookupCornerRule(o.Corners)
+	if err != nil {
+		return err
+	}
+
+	// before the toggles, they can't turn the start or the goal into a wall
+	if err := m.PickNodes(o.From, o.To); err != nil {
+		return err
+	}
+
+	m.Toggles, err = m.ParseToggles(o.Toggles)
+	if err != nil {
+		return err
+	}
+
+	m.GoalMode, err = search.LookupGoalMode(o.Goals)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+
+	"github.com/StephaneBunel/bresenham"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/tanvir-rifat007/graph-ai-search/search"
+)
+
+// path is a solution with the cell it starts at
+type path struct {
+	search.Solution
+	start search.Point
+}
+
+// paths are the plans of the agents, the K shortest paths or the one solution
+// of the maze when it has neither
+func paths(g *search.Maze) []path {
+	var ps []path
+	switch {
+	case len(g.Plans) > 0:
+		for i, plan := range g.Plans {
+			ps = append(ps, path{Solution: plan, start: g.Agents[i].Start})
+		}
+	case len(g.Paths) > 0:
+		for _, p := range g.Paths {
+			ps = append(ps, path{Solution: p, start: g.Start})
+		}
+	default:
+		ps = append(ps, path{Solution: g.Solution, start: g.Start})
+	}
+	return ps
+}
+
+// paletteColor is the color of the path or agent with the index i
+func paletteColor(i int) color.RGBA {
+	return palette[i%len(palette)]
+}
+
+// drawAgents draws the plan of every agent as a line in its own color, names
+// the start and the goal of each one and draws the agents where they are at
+// the time step of the frame
+func drawAgents(g *search.Maze, img *image.RGBA) {
+	drawPaths(g, img)
+
+	for i, a := range g.Agents {
+		printAgentLabel(g, img, a.Start, fmt.Sprintf("A%d", i+1))
+		printAgentLabel(g, img, a.Goal, fmt.Sprintf("B%d", i+1))
+	}
+
+	for i, p := range g.AgentsAt {
+		x, y := cellCenter(g, p)
+		r := image.Rect(x-16, y-16, x+16, y+16)
+		draw.Draw(img, r, &image.Uniform{C: paletteColor(i)}, image.Point{}, draw.Src)
+
+		d := &font.Drawer{
+			Dst:  img,
+			Src:  image.NewUniform(agentColor),
+			Face: basicfont.Face7x13,
+			Dot:  fixed.P(r.Min.X+13, r.Min.Y+21),
+		}
+		d.DrawString(fmt.Sprint(i + 1))
+	}
+}
+
+// drawPaths draws every path of the maze as a line in its own color, the
+// plans of the agents or the K shortest paths
+func drawPaths(g *search.Maze, img *image.RGBA) {
+	for i, p := range paths(g) {
+		c := paletteColor(i)
+
+		// every path a bit off the middle so the lines don't hide each
+		// other where they share a cell
+		shift := (i%5 - 2) * 3
+
+		from := p.start
+		for _, to := range p.Cells {
+			x1, y1 := cellCenter(g, from)
+			x2, y2 := cellCenter(g, to)
+			for o := -1; o <= 1; o++ {
+				bresenham.DrawLine(img, x1+o+shift, y1+shift, x2+o+shift, y2+shift, c)
+				bresenham.DrawLine(img, x1+shift, y1+o+shift, x2+shift, y2+o+shift, c)
+			}
+			from = to
+		}
+	}
+}
+
+// PaletteHex is the color of the path or agent with the index i as a css
+// color, so the page can name them
+func PaletteHex(i int) string {
+	c := paletteColor(i)
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// printAgentLabel writes the name of an agent's start or goal in the bottom
+// right corner of the cell
+func printAgentLabel(g *search.Maze, img *image.RGBA, p search.Point, label string) {
+	x, y := cellCenter(g, p)
+	d := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(bgColor),
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(x+8, y+25),
+	}
+	d.DrawString(label)
+}
@@ -0,0 +1,115 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"github.com/StephaneBunel/bresenham"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/tanvir-rifat007/graph-ai-search/search"
+)
+
+// floorGap is the space between two floors drawn side by side
+const floorGap = cellSize / 2
+
+// floorNameHeight is the band under the floors with their names in it
+const floorNameHeight = 20
+
+// floorSize is the size of the image of one floor, the odd rows of a hex
+// maze stick out half a hex to the right
+func floorSize(g *search.Maze) (int, int) {
+	if !g.Hex {
+		return cellSize * g.Width, cellSize * g.Height
+	}
+
+	w := math.Ceil(hexWidth * (float64(g.Width) + 0.5))
+	h := math.Ceil(hexSize * (2 + 1.5*float64(g.Height-1)))
+	return int(w) + 1, int(h) + 1
+}
+
+// imageSize is the size of the image of the maze, the floors of a maze with
+// more than one are drawn from left to right with their names under them
+func imageSize(g *search.Maze) (int, int) {
+	w, h := floorSize(g)
+	if g.Floors == nil {
+		return w, h
+	}
+
+	n := g.Levels()
+	return n*w + (n-1)*floorGap, h + floorNameHeight
+}
+
+// floorX is where the floor z starts in the image
+func floorX(g *search.Maze, z int) int {
+	w, _ := floorSize(g)
+	return z * (w + floorGap)
+}
+
+// drawGrid draws the lines between the cells of every floor
+func drawGrid(g *search.Maze, img *image.RGBA) {
+	if g.Hex {
+		drawHexGrid(g, img, gridColor)
+		return
+	}
+
+	for z := range g.Levels() {
+		x0 := floorX(g, z)
+
+		for i := range g.Floor(z) {
+			bresenham.DrawLine(img, x0, i*cellSize, x0+g.Width*cellSize, i*cellSize, gridColor)
+		}
+
+		for i := 0; i <= g.Width; i++ {
+			bresenham.DrawLine(img, x0+i*cellSize, 0, x0+i*cellSize, g.Height*cellSize, gridColor)
+		}
+	}
+}
+
+// drawFloorNames writes the name of every floor under it
+func drawFloorNames(g *search.Maze, img *image.RGBA) {
+	_, h := floorSize(g)
+
+	for z := range g.Levels() {
+		d := &font.Drawer{
+			Dst:  img,
+			Src:  image.NewUniform(textColor),
+			Face: basicfont.Face7x13,
+			Dot:  fixed.P(floorX(g, z)+6, h+15),
+		}
+		d.DrawString(fmt.Sprintf("floor %d", z))
+	}
+}
+
+// drawStairs links the two ends of every stairs the paths took with a thick
+// neon yellow line, from one floor to the next, and rings both ends
+func drawStairs(g *search.Maze, img *image.RGBA) {
+	for _, path := range paths(g) {
+		from := path.start
+		for _, to := range path.Cells {
+			if from.Z != to.Z {
+				x1, y1 := cellCenter(g, from)
+				x2, y2 := cellCenter(g, to)
+				for o := -1; o <= 1; o++ {
+					bresenham.DrawLine(img, x1+o, y1, x2+o, y2, meetingColor)
+					bresenham.DrawLine(img, x1, y1+o, x2, y2+o, meetingColor)
+				}
+
+				for _, p := range []search.Point{from, to} {
+					x, y := cellCenter(g, p)
+					for o := 0; o < 2; o++ {
+						r := image.Rect(x-24+o, y-24+o, x+24-o, y+24-o)
+						bresenham.DrawLine(img, r.Min.X, r.Min.Y, r.Max.X, r.Min.Y, meetingColor)
+						bresenham.DrawLine(img, r.Max.X, r.Min.Y, r.Max.X, r.Max.Y, meetingColor)
+						bresenham.DrawLine(img, r.Max.X, r.Max.Y, r.Min.X, r.Max.Y, meetingColor)
+						bresenham.DrawLine(img, r.Min.X, r.Max.Y, r.Min.X, r.Min.Y, meetingColor)
+					}
+				}
+			}
+			from = to
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/StephaneBunel/bresenham"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/tanvir-rifat007/graph-ai-search/search"
+)
+
+const (
+	// the size of the drawing of a graph and the space around it
+	graphWidth  = 960
+	graphHeight = 720
+	graphMargin = 60
+
+	// the most nodes and edges that still get their names and costs
+	// written next to them
+	maxNamedNodes = 60
+	maxCostEdges  = 80
+)
+
+// the edges of a graph that no path uses - dim cyan
+var edgeColor = color.RGBA{R: 0, G: 90, B: 120, A: 255}
+
+// writeGraph draws a maze loaded from a graph file as nodes joined by lines
+// instead of a grid. the colors are the ones of the cells: green start, pink
+// goal, cyan solution, purple explored
+func writeGraph(w io.Writer, g *search.Maze) error {
+	n := g.Network
+
+	img := image.NewRGBA(image.Rect(0, 0, graphWidth, graphHeight))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: bgColor}, image.Point{}, draw.Src)
+
+	at := placer(n)
+	radius := nodeRadius(n.Len())
+
+	for i := range n.Len() {
+		from := search.Point{X: i}
+		x1, y1 := at(from)
+
+		for _, e := range n.Edges(from) {
+			x2, y2 := at(e.End)
+			bresenham.DrawLine(img, x1, y1, x2, y2, edgeColor)
+
+			// a one-way edge gets an arrow, the ones that go both ways
+			// are two edges on the same line
+			back, ok := edgeCost(n, e.End, from)
+			if !ok {
+				drawArrowHead(img, x1, y1, x2, y2, radius, edgeColor)
+			}
+
+			if n.NumEdges() > maxCostEdges {
+				continue
+			}
+
+			switch {
+			case ok && back == e.Cost && from.X > e.End.X:
+				// the same cost both ways is written once
+			case ok && back != e.Cost:
+				// each way has its own cost, written closer to where
+				// that way starts
+				writeText(img, formatCost(e.Cost), x1+(x2-x1)/3+4, y1+(y2-y1)/3-4, textColor)
+			default:
+				writeText(img, formatCost(e.Cost), (x1+x2)/2+4, (y1+y2)/2-4, textColor)
+			}
+		}
+	}
+
+	// the path, or every path of Yen's algorithm in its own color
+	if len(g.Paths) > 1 {
+		for i, p := range g.Paths {
+			shift := (i%5 - 2) * 3
+			drawRoute(img, at, g.Start, p.Cells, shift, paletteColor(i))
+		}
+	} else {
+		drawRoute(img, at, g.Start, g.Solution.Cells, 0, solutionColor)
+	}
+
+	explored := cellSet(g.Explored)
+	backward := cellSet(g.BackwardExplored)
+	solution := cellSet(g.Solution.Cells)
+
+	for i := range n.Len() {
+		p := search.Point{X: i}
+		x, y := at(p)
+
+		c := emptyColor
+		switch {
+		case p == g.Start:
+			c = startColor
+		case p == g.Goal:
+			c = goalColor
+		case g.CurrentNode != nil && g.CurrentNode.State == p:
+			c = currentColor
+		case solution[p]:
+			c = solutionColor
+		case explored[p]:
+			c = exploredColor
+		case backward[p]:
+			c = backwardColor
+		}
+
+		fillCircle(img, x, y, radius, c)
+		drawCircle(img, x, y, radius, gridColor)
+
+		if n.Len() <= maxNamedNodes {
+			name := n.Name(p)
+			if cost, ok := g.Costs[p]; ok {
+				name += " g=" + formatCost(cost)
+			}
+			writeText(img, name, x+radius+3, y-radius+2, textColor)
+		}
+	}
+
+	if g.Label != "" {
+		drawLabel(g.Label, img)
+	}
+
+	return png.Encode(w, img)
+}
+
+// placer maps the coordinates of the nodes onto the image, the drawing keeps
+// its shape and y goes up
+func placer(n *search.Network) func(p search.Point) (int, int) {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, c := range n.Coords {
+		minX, maxX = min(minX, c.X), max(maxX, c.X)
+		minY, maxY = min(minY, c.Y), max(maxY, c.Y)
+	}
+
+	w := float64(graphWidth - 2*graphMargin)
+	h := float64(graphHeight - 2*graphMargin)
+
+	scale := math.Inf(1)
+	if maxX > minX {
+		scale = w / (maxX - minX)
+	}
+	if maxY > minY {
+		scale = min(scale, h/(maxY-minY))
+	}
+	if math.IsInf(scale, 1) {
+		scale = 1
+	}
+
+	// the space left over goes on both sides
+	offX := graphMargin + (w-(maxX-minX)*scale)/2
+	offY := graphMargin + (h-(maxY-minY)*scale)/2
+
+	return func(p search.Point) (int, int) {
+		c := n.Coords[p.X]
+		x := offX + (c.X-minX)*scale
+		y := offY + (maxY-c.Y)*scale
+		return int(math.Round(x)), int(math.Round(y))
+	}
+}
+
+// nodeRadius is how big the nodes are drawn, the more of them the smaller
+func nodeRadius(nodes int) int {
+	switch {
+	case nodes <= maxNamedNodes:
+		return 12
+	case nodes <= 300:
+		return 6
+	}
+	return 3
+}
+
+// edgeCost is the cost of the edge from a node to the other one, ok is false
+// when there is none
+func edgeCost(n *search.Network, from, to search.Point) (cost float64, ok bool) {
+	for _, e := range n.Edges(from) {
+		if e.End == to {
+			return e.Cost, true
+		}
+	}
+	return 0, false
+}
+
+func cellSet(cells []search.Point) map[search.Point]bool {
+	set := make(map[search.Point]bool, len(cells))
+	for _, p := range cells {
+		set[p] = true
+	}
+	return set
+}
+
+// drawRoute draws the nodes of a path one after the other as a thick line,
+// shift moves it off the middle so overlaid paths stay apart
+func drawRoute(img *image.RGBA, at func(search.Point) (int, int), start search.Point, cells []search.Point, shift int, c color.Color) {
+	from := start
+	for _, to := range cells {
+		x1, y1 := at(from)
+		x2, y2 := at(to)
+		for o := -1; o <= 1; o++ {
+			bresenham.DrawLine(img, x1+o+shift, y1+shift, x2+o+shift, y2+shift, c)
+			bresenham.DrawLine(img, x1+shift, y1+o+shift, x2+shift, y2+o+shift, c)
+		}
+		from = to
+	}
+}
+
+// drawArrowHead draws the head of the arrow from x1,y1 to x2,y2 at the edge
+// of the node it points to
+func drawArrowHead(img *image.RGBA, x1, y1, x2, y2, radius int, c color.Color) {
+	dx, dy := float64(x2-x1), float64(y2-y1)
+	d := math.Hypot(dx, dy)
+	if d <= float64(radius) {
+		return
+	}
+	dx, dy = dx/d, dy/d
+
+	tipX := float64(x2) - dx*float64(radius)
+	tipY := float64(y2) - dy*float64(radius)
+	const size = 9
+
+	for _, side := range []float64{-1, 1} {
+		// back along the edge and out to the side
+		bx := tipX - dx*size - dy*size*0.5*side
+		by := tipY - dy*size + dx*size*0.5*side
+		bresenham.DrawLine(img, int(tipX), int(tipY), int(bx), int(by), c)
+	}
+}
+
+func fillCircle(img *image.RGBA, cx, cy, r int, c color.Color) {
+	for y := -r; y <= r; y++ {
+		for x := -r; x <= r; x++ {
+			if x*x+y*y <= r*r {
+				img.Set(cx+x, cy+y, c)
+			}
+		}
+	}
+}
+
+func drawCircle(img *image.RGBA, cx, cy, r int, c color.Color) {
+	steps := 8 * r
+	for i := range steps {
+		a := 2 * math.Pi * float64(i) / float64(steps)
+		img.Set(cx+int(math.Round(float64(r)*math.Cos(a))), cy+int(math.Round(float64(r)*math.Sin(a))), c)
+	}
+}
+
+// writeText writes the text with its baseline at x,y
+func writeText(img *image.RGBA, text string, x, y int, c color.Color) {
+	d := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(c),
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(text)
+}
@@ -0,0 +1,125 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"sort"
+
+	"github.com/StephaneBunel/bresenham"
+
+	"github.com/tanvir-rifat007/graph-ai-search/search"
+)
+
+// hexSize is the distance from the middle of a hex to its corners, big enough
+// for the text of a cell to fit in the hex
+const hexSize = 42.0
+
+// hexWidth is the width of a hex from one flat side to the other, the hexes
+// of a row are this far apart
+var hexWidth = math.Sqrt(3) * hexSize
+
+// hexMask is the shape of a hex in the box around it, every hex of an image
+// is drawn through it
+var hexMask = newHexMask()
+
+func newHexMask() *image.Alpha {
+	w, h := int(math.Ceil(hexWidth)), int(math.Ceil(2*hexSize))
+	mask := image.NewAlpha(image.Rect(0, 0, w, h))
+
+	for y := range h {
+		for x := range w {
+			dx := math.Abs(float64(x) + 0.5 - float64(w)/2)
+			dy := math.Abs(float64(y) + 0.5 - float64(h)/2)
+			if dx <= hexWidth/2 && dy+dx/math.Sqrt(3) <= hexSize {
+				mask.SetAlpha(x, y, color.Alpha{A: 255})
+			}
+		}
+	}
+
+	return mask
+}
+
+// cellCenter is the middle of the cell at p in the image, on the floor of p.
+// the lines and the marks drawn over the cells go through it
+func cellCenter(g *search.Maze, p search.Point) (int, int) {
+	x0 := floorX(g, p.Z)
+
+	if !g.Hex {
+		return x0 + p.Y*cellSize + cellSize/2, p.X*cellSize + cellSize/2
+	}
+
+	x := hexWidth * (float64(p.Y) + 0.5 + 0.5*float64(p.X&1))
+	y := hexSize + 1.5*hexSize*float64(p.X)
+	return x0 + int(math.Round(x)), int(math.Round(y))
+}
+
+// hexRect is the box of the hex at p in the image, the size of the hexMask
+func hexRect(g *search.Maze, p search.Point) image.Rectangle {
+	x, y := cellCenter(g, p)
+	b := hexMask.Bounds()
+	return b.Add(image.Pt(x-b.Dx()/2, y-b.Dy()/2))
+}
+
+// patchRect is where the square patch of a cell goes, in the middle of its hex
+func patchRect(g *search.Maze, p search.Point) image.Rectangle {
+	x, y := cellCenter(g, p)
+	return image.Rect(x-cellSize/2, y-cellSize/2, x+cellSize/2, y+cellSize/2)
+}
+
+// hexCorners are the six corners of the hex at p, the hexes stand on a
+// corner so the top and the bottom are pointed
+func hexCorners(g *search.Maze, p search.Point) []image.Point {
+	x, y := cellCenter(g, p)
+
+	corners := make([]image.Point, 6)
+	for k := range corners {
+		a := math.Pi / 180 * float64(60*k-30)
+		corners[k] = image.Pt(x+int(math.Round(hexSize*math.Cos(a))), y+int(math.Round(hexSize*math.Sin(a))))
+	}
+	return corners
+}
+
+// fillHex paints the hex at p
+func fillHex(g *search.Maze, p search.Point, img *image.RGBA, c color.Color) {
+	draw.DrawMask(img, hexRect(g, p), &image.Uniform{C: c}, image.Point{}, hexMask, image.Point{}, draw.Over)
+}
+
+// drawHex is drawSquare for a hex maze, the patch of the cell with its text
+// goes in the middle of a hex painted in the same color
+func drawHex(g *search.Maze, col search.Wall, p search.Point, img *image.RGBA, c color.Color) {
+	fillHex(g, p, img, c)
+
+	r := patchRect(g, p)
+	draw.DrawMask(img, r, cellPatch(g, col, p, c, cellSize), image.Point{}, hexMask, r.Min.Sub(hexRect(g, p).Min), draw.Over)
+}
+
+// drawHexGrid draws the outline of every hex
+func drawHexGrid(g *search.Maze, img *image.RGBA, c color.Color) {
+	for z := range g.Levels() {
+		for _, row := range g.Floor(z) {
+			for _, col := range row {
+				corners := hexCorners(g, col.State)
+				for k, a := range corners {
+					b := corners[(k+1)%len(corners)]
+					bresenham.DrawLine(img, a.X, a.Y, b.X, b.Y, c)
+				}
+			}
+		}
+	}
+}
+
+// hexSide is the side the hexes at p and q share, the two corners of p that
+// are the closest to the middle of q
+func hexSide(g *search.Maze, p, q search.Point) (image.Point, image.Point) {
+	qx, qy := cellCenter(g, q)
+
+	corners := hexCorners(g, p)
+	sort.Slice(corners, func(a, b int) bool {
+		da := (corners[a].X-qx)*(corners[a].X-qx) + (corners[a].Y-qy)*(corners[a].Y-qy)
+		db := (corners[b].X-qx)*(corners[b].X-qx) + (corners[b].Y-qy)*(corners[b].Y-qy)
+		return da < db
+	})
+	return corners[0], corners[1]
+}
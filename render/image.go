@@ -0,0 +1,656 @@
+// Package render draws solved mazes as png images and apng animations.
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/StephaneBunel/bresenham"
+	"github.com/kettek/apng"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/tanvir-rifat007/graph-ai-search/search"
+)
+
+// Constant.
+const cellSize = 60
+
+// Neon cyberpunk color palette
+var (
+	// Background - deep space blue/black
+	bgColor = color.RGBA{R: 10, G: 10, B: 25, A: 255}
+
+	// Walls - dark purple
+	wallColor = color.RGBA{R: 25, G: 15, B: 45, A: 255}
+
+	// Solution path - electric cyan
+	solutionColor = color.RGBA{R: 0, G: 255, B: 255, A: 255}
+
+	// Start point - neon green
+	startColor = color.RGBA{R: 57, G: 255, B: 20, A: 255}
+
+	// Goal point - hot pink/magenta
+	goalColor = color.RGBA{R: 255, G: 0, B: 128, A: 255}
+
+	// Current node - electric purple
+	currentColor = color.RGBA{R: 191, G: 64, B: 191, A: 255}
+
+	// Explored cells - deep blue with purple tint
+	exploredColor = color.RGBA{R: 75, G: 50, B: 150, A: 255}
+
+	// Cells explored from the goal by a bidirectional search - burnt orange
+	backwardColor = color.RGBA{R: 150, G: 70, B: 30, A: 255}
+
+	// Where the two halves of a bidirectional search met - neon yellow
+	meetingColor = color.RGBA{R: 255, G: 230, B: 0, A: 255}
+
+	// Jump points of Jump Point Search - electric orange
+	jumpPointColor = color.RGBA{R: 255, G: 140, B: 0, A: 255}
+
+	// The agent of D* Lite - bright white
+	agentColor = color.RGBA{R: 240, G: 240, B: 255, A: 255}
+
+	// Cells the agent already walked through - dark teal
+	trailColor = color.RGBA{R: 0, G: 110, B: 120, A: 255}
+
+	// Walls that were just added or taken away - warning red
+	changedColor = color.RGBA{R: 220, G: 30, B: 50, A: 255}
+
+	// Keys lying in the maze - old gold
+	keyColor = color.RGBA{R: 170, G: 140, B: 20, A: 255}
+
+	// Locked doors - steel grey
+	doorColor = color.RGBA{R: 80, G: 85, B: 110, A: 255}
+
+	// Portals and the links between them - ultraviolet
+	portalColor = color.RGBA{R: 120, G: 50, B: 255, A: 255}
+
+	// Stairs between the floors - jade
+	stairsColor = color.RGBA{R: 40, G: 140, B: 100, A: 255}
+
+	// Moving obstacles and their routes - alarm orange
+	guardColor = color.RGBA{R: 255, G: 80, B: 20, A: 255}
+
+	// Empty cells - darker blue
+	emptyColor = color.RGBA{R: 20, G: 25, B: 45, A: 255}
+
+	// Heaviest terrain cells - muddy orange, lighter terrain is mixed
+	// between this and the empty color
+	terrainColor = color.RGBA{R: 120, G: 70, B: 20, A: 255}
+
+	// Grid lines - bright cyan with glow effect
+	gridColor = color.RGBA{R: 0, G: 200, B: 255, A: 255}
+
+	// Text color - bright cyan
+	textColor = color.RGBA{R: 0, G: 255, B: 255, A: 255}
+)
+
+// OutputImage draw the maze as png file with neon theme.
+func OutputImage(g *search.Maze, fileName string) error {
+	fmt.Printf("🎨 Generating neon cyberpunk maze image %s...\n", fileName)
+
+	f, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return WriteImage(f, g)
+}
+
+// WriteImage encodes the maze as png into w, a maze loaded from a graph file
+// is drawn as its nodes and edges
+func WriteImage(w io.Writer, g *search.Maze) error {
+	if g.Network != nil {
+		return writeGraph(w, g)
+	}
+
+	width, height := imageSize(g)
+
+	upLeft := image.Point{}
+	lowRight := image.Point{X: width, Y: height}
+
+	img := image.NewRGBA(image.Rectangle{Min: upLeft, Max: lowRight})
+
+	// Dark cyberpunk background
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: bgColor}, image.Point{}, draw.Src)
+
+	// draw squares on the image with neon colors, the floors side by side
+	for z := range g.Levels() {
+		x0 := floorX(g, z)
+
+		for i, row := range g.Floor(z) {
+			for j, col := range row {
+				p := col.State
+				if g.Hex {
+					drawHex(g, col, p, img, cellColor(g, col, p))
+				} else {
+					drawSquare(g, col, p, img, cellColor(g, col, p), cellSize, x0+j*cellSize, i*cellSize)
+				}
+			}
+		}
+	}
+
+	// draw a glowing grid with neon cyan lines
+	drawGrid(g, img)
+
+	if g.Floors != nil {
+		drawFloorNames(g, img)
+		drawStairs(g, img)
+	}
+
+	if len(g.Portals) > 0 {
+		drawPortals(g, img)
+	}
+
+	if len(g.Agents) > 0 {
+		drawAgents(g, img)
+	} else if len(g.Paths) > 1 {
+		drawPaths(g, img)
+	}
+
+	if len(g.Obstacles) > 0 {
+		drawObstacles(g, img)
+	}
+
+	if g.Label != "" {
+		drawLabel(g.Label, img)
+	}
+
+	return png.Encode(w, img)
+}
+
+// cellColor is the color of the cell at p, what the cell is and what the
+// search did with it decide it
+func cellColor(g *search.Maze, col search.Wall, p search.Point) color.Color {
+	if g.IsChanged(p) {
+		// the wall was just flipped, warning red
+		return changedColor
+	}
+	if col.IsWall {
+		// draw dark purple square for wall
+		return wallColor
+	}
+	if g.Agent != nil && *g.Agent == p {
+		// where the agent is right now, bright white
+		return agentColor
+	}
+	if g.MeetingPoint != nil && *g.MeetingPoint == p {
+		// the two searches met here, neon yellow
+		return meetingColor
+	}
+	if g.InSolution(p) {
+		// part of solution, so draw electric cyan square
+		return solutionColor
+	}
+	if g.InTrail(p) && g.Agent != nil {
+		// the agent already walked here, dark teal
+		return trailColor
+	}
+	if g.IsStart(p) {
+		// Starting point, so draw neon green square
+		return startColor
+	}
+	if g.IsGoal(p) {
+		// Ending point. Draw hot pink square
+		return goalColor
+	}
+	if g.CurrentNode != nil && col.State == g.CurrentNode.State {
+		// Current location. Draw in electric purple
+		return currentColor
+	}
+	if col.Key != 0 {
+		// a key to pick up, old gold
+		return keyColor
+	}
+	if col.Door != 0 {
+		// a door that needs its key, steel grey
+		return doorColor
+	}
+	if col.Portal != 0 {
+		// a portal, ultraviolet
+		return portalColor
+	}
+	if col.Stairs != 0 {
+		// stairs to another floor, jade
+		return stairsColor
+	}
+	if g.IsJumpPoint(p) {
+		// a jump point, expanded by Jump Point Search - electric orange
+		return jumpPointColor
+	}
+	if g.InExplored(p) {
+		// An explored cell - deep blue purple
+		return exploredColor
+	}
+	if g.InBackwardExplored(p) {
+		// explored from the goal side - burnt orange
+		return backwardColor
+	}
+	if col.Cost > 1 {
+		// weighted terrain, the heavier the more orange
+		return weightColor(col.Cost)
+	}
+
+	// empty, unexplored. Draw in dark blue
+	return emptyColor
+}
+
+// drawSquare with neon styling
+func drawSquare(g *search.Maze, col search.Wall, p search.Point, img *image.RGBA, c color.Color, size, x, y int) {
+	draw.Draw(img, image.Rect(x, y, x+size, y+size), cellPatch(g, col, p, c, size), image.Point{}, draw.Src)
+}
+
+// cellPatch is a cell painted in c with its arrow and its text on top
+func cellPatch(g *search.Maze, col search.Wall, p search.Point, c color.Color, size int) *image.RGBA {
+	patch := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(patch, patch.Bounds(), &image.Uniform{
+		C: c,
+	}, image.Point{}, draw.Src)
+
+	// the arrow goes under the text so the costs stay readable
+	if !col.IsWall && col.OneWay != (search.Point{}) {
+		drawArrow(col.OneWay, arrowColor(c), patch)
+	}
+
+	if !col.IsWall {
+		// Choose text color based on background brightness
+		var txtColor color.Color
+		// Use dark text for bright backgrounds, bright text for dark backgrounds
+		if isBrightColor(c) {
+			txtColor = color.RGBA{R: 10, G: 10, B: 25, A: 255} // Dark blue text
+		} else {
+			txtColor = textColor // Cyan text
+		}
+
+		// add the manhattan cost to our each node/square
+
+		switch g.SearchType {
+
+		case search.DIJKSTRA:
+			printManhattanCost(g, p, txtColor, patch)
+		case search.GBFS:
+			printManhattanCost(g, p, txtColor, patch)
+		case search.ASTAR, search.JPS, search.IDASTAR, search.WASTAR, search.ARASTAR, search.LPASTAR:
+			printTotalCost(g, p, txtColor, patch)
+		default:
+		}
+
+		printLocation(p, txtColor, patch)
+
+		if col.Cost > 1 {
+			printWeight(col.Cost, txtColor, patch)
+		}
+
+		if col.Key != 0 || col.Door != 0 {
+			printKeyOrDoor(col, txtColor, patch)
+		}
+
+		if col.Portal != 0 {
+			printPortal(col.Portal, txtColor, patch)
+		}
+
+		if col.Stairs != 0 {
+			printStairs(col.Stairs, txtColor, patch)
+		}
+
+		if stop, ok := g.TourStop(p); ok {
+			printTourStop(stop, txtColor, patch)
+		}
+
+		// the keys the solution held when it went through here
+		if keys, ok := g.KeysAt(p); ok {
+			printKeys(keys, txtColor, patch)
+		}
+	}
+
+	return patch
+}
+
+// drawLabel writes the maze's Label on a dark band in the top left corner
+func drawLabel(label string, img *image.RGBA) {
+	band := image.Rect(0, 0, 7*len([]rune(label))+12, 20)
+	draw.Draw(img, band, &image.Uniform{C: bgColor}, image.Point{}, draw.Src)
+
+	d := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(meetingColor),
+		Face: basicfont.Face7x13,
+		Dot:  fixed.Point26_6{X: fixed.I(6), Y: fixed.I(14)},
+	}
+
+	d.DrawString(label)
+}
+
+func printTotalCost(g *search.Maze, p search.Point, c color.Color, patch *image.RGBA) {
+
+	// position where should i write the cost on each square
+	point := fixed.Point26_6{X: fixed.I(6), Y: fixed.I(17)}
+
+	d := &font.Drawer{
+		Dst:  patch,
+		Src:  image.NewUniform(c),
+		Face: basicfont.Face7x13,
+		Dot:  point,
+	}
+
+	fromCurrToGoal := g.Estimate(p)
+
+	// f = g + w·h for the explored cells, the others only have their h.
+	// w is 1 but for weighted A* and ARA*, f is what they order the cells by
+	if fromStartToCurrCost, ok := g.Costs[p]; ok {
+		d.DrawString(fmt.Sprintf("f=%.1f", fromStartToCurrCost+g.HeuristicWeight()*fromCurrToGoal))
+	} else {
+		d.DrawString(fmt.Sprintf("h=%.1f", fromCurrToGoal))
+	}
+
+}
+
+func printManhattanCost(g *search.Maze, p search.Point, c color.Color, patch *image.RGBA) {
+
+	// position where should i write the cost on each square
+	point := fixed.Point26_6{X: fixed.I(6), Y: fixed.I(17)}
+
+	d := &font.Drawer{
+		Dst:  patch,
+		Src:  image.NewUniform(c),
+		Face: basicfont.Face7x13,
+		Dot:  point,
+	}
+
+	switch g.SearchType {
+
+	case search.DIJKSTRA:
+		// the real cost from the start is only known for explored cells
+		if cost, ok := g.Costs[p]; ok {
+			d.DrawString(formatCost(cost))
+		}
+	case search.GBFS:
+		// the heuristic that the search used for this cell
+		d.DrawString(formatCost(g.Estimate(p)))
+
+	default:
+
+	}
+
+}
+
+// printLocation with cyberpunk styling
+func printLocation(p search.Point, c color.Color, patch *image.RGBA) {
+	point := fixed.Point26_6{X: fixed.I(6), Y: fixed.I(40)}
+	d := &font.Drawer{
+		Dst:  patch,
+		Src:  image.NewUniform(c),
+		Face: basicfont.Face7x13,
+		Dot:  point,
+	}
+
+	d.DrawString(fmt.Sprintf("[%d %d]", p.X, p.Y))
+}
+
+// formatCost drops the decimals of whole costs so they fit in the square
+func formatCost(cost float64) string {
+	if cost == math.Trunc(cost) {
+		return fmt.Sprintf("%d", int(cost))
+	}
+	return fmt.Sprintf("%.1f", cost)
+}
+
+// printWeight writes the move cost of a weighted cell
+func printWeight(cost int, c color.Color, patch *image.RGBA) {
+	point := fixed.Point26_6{X: fixed.I(6), Y: fixed.I(55)}
+	d := &font.Drawer{
+		Dst:  patch,
+		Src:  image.NewUniform(c),
+		Face: basicfont.Face7x13,
+		Dot:  point,
+	}
+
+	d.DrawString(fmt.Sprintf("w%d", cost))
+}
+
+// printKeyOrDoor writes the letter of the key or the door in the cell
+func printKeyOrDoor(col search.Wall, c color.Color, patch *image.RGBA) {
+	point := fixed.Point26_6{X: fixed.I(6), Y: fixed.I(55)}
+	d := &font.Drawer{
+		Dst:  patch,
+		Src:  image.NewUniform(c),
+		Face: basicfont.Face7x13,
+		Dot:  point,
+	}
+
+	if col.Key != 0 {
+		d.DrawString("key " + col.Key.String())
+	} else {
+		d.DrawString("door " + strings.ToUpper(col.Door.String()))
+	}
+}
+
+// printPortal writes the character of the portal in the cell
+func printPortal(r rune, c color.Color, patch *image.RGBA) {
+	point := fixed.Point26_6{X: fixed.I(6), Y: fixed.I(55)}
+	d := &font.Drawer{
+		Dst:  patch,
+		Src:  image.NewUniform(c),
+		Face: basicfont.Face7x13,
+		Dot:  point,
+	}
+
+	d.DrawString("warp " + string(r))
+}
+
+// printStairs writes which way the stairs in the cell go
+func printStairs(stairs int, c color.Color, patch *image.RGBA) {
+	point := fixed.Point26_6{X: fixed.I(6), Y: fixed.I(55)}
+	d := &font.Drawer{
+		Dst:  patch,
+		Src:  image.NewUniform(c),
+		Face: basicfont.Face7x13,
+		Dot:  point,
+	}
+
+	if stairs > 0 {
+		d.DrawString("up")
+	} else {
+		d.DrawString("down")
+	}
+}
+
+// printTourStop writes when the tour visits the goal
+func printTourStop(stop int, c color.Color, patch *image.RGBA) {
+	point := fixed.Point26_6{X: fixed.I(6), Y: fixed.I(55)}
+	d := &font.Drawer{
+		Dst:  patch,
+		Src:  image.NewUniform(c),
+		Face: basicfont.Face7x13,
+		Dot:  point,
+	}
+
+	d.DrawString(fmt.Sprintf("goal %d", stop))
+}
+
+// drawPortals links every pair of portals with a thin line, the teleports
+// the solution made get a thick neon yellow one on top
+func drawPortals(g *search.Maze, img *image.RGBA) {
+	for p, q := range g.Portals {
+		x1, y1 := cellCenter(g, p)
+		x2, y2 := cellCenter(g, q)
+		bresenham.DrawLine(img, x1, y1, x2, y2, portalColor)
+	}
+
+	for _, path := range paths(g) {
+		from := path.start
+		for i, to := range path.Cells {
+			if path.Actions[i] == "teleport" {
+				x1, y1 := cellCenter(g, from)
+				x2, y2 := cellCenter(g, to)
+				for o := -1; o <= 1; o++ {
+					bresenham.DrawLine(img, x1+o, y1, x2+o, y2, meetingColor)
+					bresenham.DrawLine(img, x1, y1+o, x2, y2+o, meetingColor)
+				}
+			}
+			from = to
+		}
+	}
+}
+
+// printKeys writes the key set held on a solution step
+func printKeys(keys search.Keys, c color.Color, patch *image.RGBA) {
+	point := fixed.Point26_6{X: fixed.I(6), Y: fixed.I(29)}
+	d := &font.Drawer{
+		Dst:  patch,
+		Src:  image.NewUniform(c),
+		Face: basicfont.Face7x13,
+		Dot:  point,
+	}
+
+	d.DrawString("k:" + keys.String())
+}
+
+// weightColor mixes the empty color and the terrain color by the cost,
+// cost 9 is the heaviest terrain a maze file can have
+func weightColor(cost int) color.Color {
+	t := float64(min(cost, 9)-1) / 8
+
+	mix := func(a, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*t)
+	}
+
+	return color.RGBA{
+		R: mix(emptyColor.R, terrainColor.R),
+		G: mix(emptyColor.G, terrainColor.G),
+		B: mix(emptyColor.B, terrainColor.B),
+		A: 255,
+	}
+}
+
+// drawArrow draws a big arrow across the cell in the direction d of a one-way
+// cell, d is a move in rows and columns so the x of the image is its Y
+func drawArrow(d search.Point, c color.Color, patch *image.RGBA) {
+	mid := cellSize / 2
+	fx, fy := d.Y, d.X
+	// the side is the forward direction turned by 90 degrees
+	sx, sy := -fy, fx
+
+	tailX, tailY := mid-18*fx, mid-18*fy
+	tipX, tipY := mid+18*fx, mid+18*fy
+
+	for o := -1; o <= 1; o++ {
+		bresenham.DrawLine(patch, tailX+o*sx, tailY+o*sy, tipX+o*sx, tipY+o*sy, c)
+		for _, side := range []int{-1, 1} {
+			wingX, wingY := tipX-10*fx+side*10*sx, tipY-10*fy+side*10*sy
+			bresenham.DrawLine(patch, tipX+o*fx, tipY+o*fy, wingX+o*fx, wingY+o*fy, c)
+		}
+	}
+}
+
+// arrowColor is a bit lighter than a dark cell and a bit darker than a bright
+// one, so the arrow shows without hiding the text on top of it
+func arrowColor(c color.Color) color.Color {
+	r, g, b, _ := c.RGBA()
+
+	var to float64 = 255
+	if isBrightColor(c) {
+		to = 0
+	}
+
+	mix := func(v uint32) uint8 {
+		return uint8(float64(v>>8) + (to-float64(v>>8))*0.4)
+	}
+
+	return color.RGBA{R: mix(r), G: mix(g), B: mix(b), A: 255}
+}
+
+// isBrightColor determines if a color is bright (needs dark text)
+func isBrightColor(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	// Convert to 0-255 range and calculate perceived brightness
+	brightness := (r>>8)*299 + (g>>8)*587 + (b>>8)*114
+	return brightness > 128000 // Threshold for bright colors
+}
+
+// Frames returns a hook for Maze.Frame that writes every animation
+// frame as a numbered png into dir
+func Frames(dir string) func(m *search.Maze) {
+	n := 0
+	return func(m *search.Maze) {
+		n++
+		_ = OutputImage(m, filepath.Join(dir, fmt.Sprintf("%06d.png", n)))
+	}
+}
+
+// ClearFrames deletes the frames left over in dir from an earlier run
+func ClearFrames(dir string) {
+	filesToDelete, _ := os.ReadDir(dir)
+
+	for _, f := range filesToDelete {
+		_ = os.Remove(filepath.Join(dir, f.Name()))
+	}
+}
+
+// OutputAnimatedImage creates animated maze visualization with proper delays,
+// the frames in dir are played in order and the final image comes last
+func OutputAnimatedImage(dir, finalImage, output string) error {
+	fmt.Println("🎬 Creating cyberpunk animated maze...")
+
+	files, _ := os.ReadDir(dir)
+
+	var images []string
+
+	for _, file := range files {
+		images = append(images, filepath.Join(dir, file.Name()))
+	}
+	images = append(images, finalImage)
+
+	var a apng.APNG
+
+	for _, s := range images {
+		m, err := decodePNG(s)
+		if err != nil {
+			return err
+		}
+
+		// Set both the image AND the delay
+		// Set delay correctly: Numerator / Denominator = seconds
+		// For 10ms: 300/1000 = 0.3 seconds
+		a.Frames = append(a.Frames, apng.Frame{
+			Image:            m,
+			DelayNumerator:   300,
+			DelayDenominator: 1000,
+		})
+	}
+
+	out, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if err := apng.Encode(out, a); err != nil {
+		return err
+	}
+
+	fmt.Println("✨ Neon maze animation complete!")
+
+	return nil
+}
+
+func decodePNG(fileName string) (image.Image, error) {
+	in, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+
+	return png.Decode(in)
+}
@@ -0,0 +1,42 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+
+	"github.com/StephaneBunel/bresenham"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/tanvir-rifat007/graph-ai-search/search"
+)
+
+// drawObstacles draws the route of every guard as a thin line round the cells
+// it walks through, and the guards where they are at the time step of the
+// frame as squares named G1, G2 and so on
+func drawObstacles(g *search.Maze, img *image.RGBA) {
+	for _, o := range g.Obstacles {
+		for i, from := range o.Route {
+			to := o.Route[(i+1)%len(o.Route)]
+			x1, y1 := cellCenter(g, from)
+			x2, y2 := cellCenter(g, to)
+			bresenham.DrawLine(img, x1, y1, x2, y2, guardColor)
+		}
+	}
+
+	for i, p := range g.ObstaclesAt(g.Time) {
+		x, y := cellCenter(g, p)
+		r := image.Rect(x-20, y-20, x+20, y+20)
+		draw.Draw(img, r, &image.Uniform{C: guardColor}, image.Point{}, draw.Src)
+
+		d := &font.Drawer{
+			Dst:  img,
+			Src:  image.NewUniform(bgColor),
+			Face: basicfont.Face7x13,
+			Dot:  fixed.P(r.Min.X+13, r.Min.Y+25),
+		}
+		d.DrawString(fmt.Sprintf("G%d", i+1))
+	}
+}
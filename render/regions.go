@@ -0,0 +1,193 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"os"
+
+	"github.com/StephaneBunel/bresenham"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/tanvir-rifat007/graph-ai-search/search"
+)
+
+// palette tells the regions of the starts and the agents apart, in the order
+// of the starts. with more starts than colors they go around again
+var palette = []color.RGBA{
+	{R: 0, G: 140, B: 160, A: 255},  // teal
+	{R: 170, G: 40, B: 120, A: 255}, // magenta
+	{R: 60, G: 150, B: 40, A: 255},  // green
+	{R: 180, G: 110, B: 20, A: 255}, // amber
+	{R: 90, G: 70, B: 190, A: 255},  // indigo
+	{R: 170, G: 50, B: 40, A: 255},  // brick
+	{R: 40, G: 100, B: 180, A: 255}, // blue
+	{R: 130, G: 140, B: 30, A: 255}, // olive
+}
+
+// OutputRegions draws which start is the closest to every cell as png file
+func OutputRegions(g *search.Maze, fileName string) error {
+	fmt.Printf("🎨 Generating start regions image %s...\n", fileName)
+
+	f, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return WriteRegions(f, g)
+}
+
+// WriteRegions encodes the regions of the starts as png into w. every start
+// gets its own color, the cells show the cost from their closest start and
+// the solution is drawn on top as a line
+func WriteRegions(w io.Writer, g *search.Maze) error {
+	width, height := imageSize(g)
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: bgColor}, image.Point{}, draw.Src)
+
+	for z := range g.Levels() {
+		for _, row := range g.Floor(z) {
+			for _, col := range row {
+				p := col.State
+				r := patchRect(g, p)
+
+				owner, owned := g.Regions[p]
+
+				var c color.Color
+				switch {
+				case col.IsWall:
+					c = wallColor
+				case g.IsGoal(p):
+					c = goalColor
+				case g.IsStart(p):
+					c = startColor
+				case owned:
+					c = paletteColor(owner)
+				default:
+					// no start gets here
+					c = emptyColor
+				}
+
+				if g.Hex {
+					fillHex(g, p, img, c)
+				} else {
+					draw.Draw(img, r, &image.Uniform{C: c}, image.Point{}, draw.Src)
+				}
+
+				if col.IsWall {
+					continue
+				}
+
+				txt := textColor
+				if isBrightColor(c) {
+					txt = bgColor
+				}
+
+				patch := img.SubImage(r).(*image.RGBA)
+				d := &font.Drawer{
+					Dst:  patch,
+					Src:  image.NewUniform(txt),
+					Face: basicfont.Face7x13,
+				}
+
+				if cost, ok := g.RegionCosts[p]; ok {
+					d.Dot = fixed.P(r.Min.X+6, r.Min.Y+17)
+					d.DrawString("d=" + formatCost(cost))
+				}
+
+				d.Dot = fixed.P(r.Min.X+6, r.Min.Y+40)
+				d.DrawString(fmt.Sprintf("[%d %d]", p.X, p.Y))
+
+				if n := g.StartIndex(p); n >= 0 {
+					d.Dot = fixed.P(r.Min.X+6, r.Min.Y+55)
+					d.DrawString(fmt.Sprintf("start %d", n+1))
+				}
+			}
+		}
+	}
+
+	drawGrid(g, img)
+
+	if g.Floors != nil {
+		drawFloorNames(g, img)
+	}
+
+	drawBorders(g, img)
+	drawPath(g, img)
+
+	return png.Encode(w, img)
+}
+
+// drawBorders draws a thick line between two open cells that belong to
+// different starts, those are the edges of the regions
+func drawBorders(g *search.Maze, img *image.RGBA) {
+	if g.Hex {
+		drawHexBorders(g, img)
+		return
+	}
+
+	for p, owner := range g.Regions {
+		// the cell below and the one to the right, so every border is
+		// drawn once
+		x0 := floorX(g, p.Z)
+
+		for _, q := range []search.Point{{X: p.X + 1, Y: p.Y, Z: p.Z}, {X: p.X, Y: p.Y + 1, Z: p.Z}} {
+			other, ok := g.Regions[q]
+			if !ok || other == owner {
+				continue
+			}
+
+			for o := -1; o <= 1; o++ {
+				if q.X != p.X {
+					y := q.X*cellSize + o
+					bresenham.DrawLine(img, x0+p.Y*cellSize, y, x0+(p.Y+1)*cellSize, y, agentColor)
+				} else {
+					x := x0 + q.Y*cellSize + o
+					bresenham.DrawLine(img, x, p.X*cellSize, x, (p.X+1)*cellSize, agentColor)
+				}
+			}
+		}
+	}
+}
+
+// drawHexBorders is drawBorders for a hex maze, the side two hexes share is
+// drawn from the right, down-left and down-right of every hex
+func drawHexBorders(g *search.Maze, img *image.RGBA) {
+	for p, owner := range g.Regions {
+		q, r := search.Axial(p)
+		for _, d := range []search.HexDirection{search.HexDirections[0], search.HexDirections[4], search.HexDirections[5]} {
+			n := search.FromAxial(q+d.Q, r+d.R)
+			n.Z = p.Z
+			other, ok := g.Regions[n]
+			if !ok || other == owner {
+				continue
+			}
+
+			a, b := hexSide(g, p, n)
+			for o := -1; o <= 1; o++ {
+				bresenham.DrawLine(img, a.X+o, a.Y, b.X+o, b.Y, agentColor)
+				bresenham.DrawLine(img, a.X, a.Y+o, b.X, b.Y+o, agentColor)
+			}
+		}
+	}
+}
+
+// drawPath draws the solution as a thick line through the middle of its cells
+func drawPath(g *search.Maze, img *image.RGBA) {
+	from := g.Start
+	for _, to := range g.Solution.Cells {
+		x1, y1 := cellCenter(g, from)
+		x2, y2 := cellCenter(g, to)
+		for o := -1; o <= 1; o++ {
+			bresenham.DrawLine(img, x1+o, y1, x2+o, y2, meetingColor)
+			bresenham.DrawLine(img, x1, y1+o, x2, y2+o, meetingColor)
+		}
+		from = to
+	}
+}
@@ -0,0 +1,170 @@
+package search
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Agent is one robot of a multi-agent search, it goes from its start to its
+// goal. in the maze file agent 1 starts at "A1" and ends at "B1", agent 2
+// goes from "A2" to "B2" and so on
+type Agent struct {
+	Start, Goal Point
+}
+
+// rowCells splits a row of the maze file into its cells. an "A" or a "B"
+// with a number right after it is one cell, the start or the goal of the
+// agent with that number. numbers has the number of every cell, 0 for the
+// cells without one
+func rowCells(row string) (cells []rune, numbers []int) {
+	rs := []rune(row)
+
+	for j := 0; j < len(rs); j++ {
+		cells = append(cells, rs[j])
+		numbers = append(numbers, 0)
+
+		if (rs[j] != 'A' && rs[j] != 'B') || j+1 == len(rs) || rs[j+1] < '1' || rs[j+1] > '9' {
+			continue
+		}
+
+		n := 0
+		for j+1 < len(rs) && '0' <= rs[j+1] && rs[j+1] <= '9' {
+			j++
+			n = 10*n + int(rs[j]-'0')
+		}
+		numbers[len(numbers)-1] = n
+	}
+
+	return cells, numbers
+}
+
+// numberAgents makes the agents out of the numbered starts and goals of the
+// file. once one start or goal has a number they all need one, and every
+// agent from 1 up to the last one needs both its "A" and its "B"
+func (g *Maze) numberAgents(starts, goals map[int]Point) error {
+	if len(starts) != len(g.Starts) || len(goals) != len(g.Goals) {
+		return fmt.Errorf("the maze has numbered agents, every \"A\" and \"B\" needs a number like A1 and B1 (a digit right after an \"A\" or a \"B\" is its number, not a cost)")
+	}
+
+	n := max(len(starts), len(goals))
+	g.Agents = make([]Agent, n)
+
+	for i := 1; i <= n; i++ {
+		start, ok := starts[i]
+		if !ok {
+			return fmt.Errorf("agent %d has no start A%d", i, i)
+		}
+		goal, ok := goals[i]
+		if !ok {
+			return fmt.Errorf("agent %d has no goal B%d", i, i)
+		}
+
+		g.Agents[i-1] = Agent{Start: start, Goal: goal}
+	}
+
+	return nil
+}
+
+// pairAgents checks the agents of the maze before a multi-agent search. a
+// maze with one start and one goal is agent 1, a maze with more has to
+// number them
+func (g *Maze) pairAgents() error {
+	starts, goals := g.starts(), g.Goals
+	if len(goals) == 0 {
+		goals = []Point{g.Goal}
+	}
+
+	if len(starts) == 1 && len(goals) == 1 {
+		g.Agents = []Agent{{Start: starts[0], Goal: goals[0]}}
+		return nil
+	}
+
+	if g.Agents == nil {
+		return fmt.Errorf("the maze has %d starts and %d goals, number the agents A1 and B1, A2 and B2 and so on", len(starts), len(goals))
+	}
+
+	// the start and the goal can be moved after the file was read
+	if len(starts) != len(g.Agents) || len(goals) != len(g.Agents) {
+		return fmt.Errorf("the maze has %d starts and %d goals for %d agents, every agent needs one of each", len(starts), len(goals), len(g.Agents))
+	}
+	for i, a := range g.Agents {
+		if !slices.Contains(starts, a.Start) || !slices.Contains(goals, a.Goal) {
+			return fmt.Errorf("agent %d does not go from a start to a goal of the maze", i+1)
+		}
+	}
+
+	return nil
+}
+
+// AgentOf is the number (from 1) of the agent that starts or ends at p, 0
+// when there is none. start is true when p is the agent's start
+func (g *Maze) AgentOf(p Point) (n int, start bool) {
+	for i, a := range g.Agents {
+		switch p {
+		case a.Start:
+			return i + 1, true
+		case a.Goal:
+			return i + 1, false
+		}
+	}
+	return 0, false
+}
+
+// Makespan is the number of time steps until the last agent is done
+func (g *Maze) Makespan() int {
+	steps := 0
+	for _, plan := range g.Plans {
+		steps = max(steps, len(plan.Cells))
+	}
+	return steps
+}
+
+// conflict is two agents that are in the same cell at the same time, or
+// that swap their cells in the same time step
+type conflict struct {
+	a, b int
+	t    int
+	// the cell of a vertex conflict
+	cell Point
+	// an edge conflict, a moves from to cell while b moves the other way
+	edge bool
+	from Point
+}
+
+// conflicts finds every conflict between the plans of the agents, in the
+// order of time
+func conflicts(agents []Agent, plans []Solution) []conflict {
+	steps := 0
+	for _, plan := range plans {
+		steps = max(steps, len(plan.Cells))
+	}
+
+	var found []conflict
+
+	for t := 0; t <= steps; t++ {
+		for a := range agents {
+			for b := a + 1; b < len(agents); b++ {
+				pa := position(plans[a], agents[a].Start, t)
+				pb := position(plans[b], agents[b].Start, t)
+
+				if pa == pb {
+					found = append(found, conflict{a: a, b: b, t: t, cell: pa})
+					continue
+				}
+
+				if t == 0 {
+					continue
+				}
+
+				qa := position(plans[a], agents[a].Start, t-1)
+				qb := position(plans[b], agents[b].Start, t-1)
+
+				if qa == pb && qb == pa {
+					found = append(found, conflict{a: a, b: b, t: t, cell: pa, edge: true, from: qa})
+				}
+			}
+		}
+	}
+
+	return found
+}
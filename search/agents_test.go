@@ -0,0 +1,172 @@
+package search
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRowCells(t *testing.T) {
+	tests := []struct {
+		row     string
+		cells   string
+		numbers []int
+	}{
+		{"#A #", "#A #", []int{0, 0, 0, 0}},
+		{"#A1 B12#", "#A B#", []int{0, 1, 0, 12, 0}},
+		{"A3B", "AB", []int{3, 0}},
+		// a number can't start with 0, that's a cell of its own
+		{"A0", "A0", []int{0, 0}},
+		// digits after anything else are costs
+		{" 12", " 12", []int{0, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.row, func(t *testing.T) {
+			cells, numbers := rowCells(tt.row)
+			if string(cells) != tt.cells || !slices.Equal(numbers, tt.numbers) {
+				t.Errorf("cells %q numbers %v, want %q and %v", string(cells), numbers, tt.cells, tt.numbers)
+			}
+		})
+	}
+}
+
+func TestNumberedAgents(t *testing.T) {
+	m, err := NewMaze(strings.NewReader("#B2 A1#\n#   #\n#A2 B1#\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Agent{
+		{Start: Point{X: 0, Y: 3}, Goal: Point{X: 2, Y: 3}},
+		{Start: Point{X: 2, Y: 1}, Goal: Point{X: 0, Y: 1}},
+	}
+	if !slices.Equal(m.Agents, want) {
+		t.Errorf("agents %v, want %v", m.Agents, want)
+	}
+	if m.Width != 5 {
+		t.Errorf("width %d, want 5, a numbered start is one cell", m.Width)
+	}
+}
+
+func TestNumberedAgentsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		err  string
+	}{
+		{"missing goal", "A1 A2 B1\n", "agent 2 has no goal B2"},
+		{"missing start", "A1 B1 B2\n", "agent 2 has no start A2"},
+		{"gap", "A1 A3 B1 B3\n", "agent 2 has no start A2"},
+		{"start twice", "A1 A1 B1\n", "A1 at line 1: agent 1 has another start"},
+		{"goal twice", "A1 B1\nB1\n", "B1 at line 2: agent 1 has another goal"},
+		{"some without a number", "A1 A B1 B\n", `every "A" and "B" needs a number`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewMaze(strings.NewReader(tt.src))
+			if err == nil {
+				t.Fatalf("no error, want %q", tt.err)
+			}
+			if !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("error %q, want %q in it", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestPairAgents(t *testing.T) {
+	tests := []struct {
+		name   string
+		src    string
+		agents int
+		err    string
+	}{
+		{"one start and goal", "A  B\n", 1, ""},
+		{"numbered", "A1 A2 B2 B1\n", 2, ""},
+		{"several without numbers", "A A B B\n", 0, "number the agents A1 and B1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMaze(strings.NewReader(tt.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = m.pairAgents()
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("error %v, want %q in it", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(m.Agents) != tt.agents {
+				t.Errorf("%d agents, want %d", len(m.Agents), tt.agents)
+			}
+		})
+	}
+}
+
+// the plans of CBS never have two agents in one cell at the same time or two
+// agents that swap their cells
+func TestConflictBasedSearch(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{"swap in a corridor with a bay", "### ###\n#B2A1 A2B1#\n#######\n"},
+		{"crossing", "#####\n##A2##\n#A1 B1#\n## ##\n##B2##\n"},
+		{"bundled", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m *Maze
+			var err error
+			if tt.src == "" {
+				m, err = LoadMaze("../maze-agents.txt")
+			} else {
+				m, err = NewMaze(strings.NewReader(tt.src))
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := Solve("CBS", m); err != nil {
+				t.Fatal(err)
+			}
+			if len(m.Plans) != len(m.Agents) {
+				t.Fatalf("%d plans for %d agents", len(m.Plans), len(m.Agents))
+			}
+
+			for i, plan := range m.Plans {
+				if len(plan.Cells) == 0 || plan.Cells[len(plan.Cells)-1] != m.Agents[i].Goal {
+					t.Errorf("agent %d doesn't get to its goal", i+1)
+				}
+			}
+
+			for step := 1; step <= m.Makespan(); step++ {
+				for a := range m.Agents {
+					for b := a + 1; b < len(m.Agents); b++ {
+						pa := position(m.Plans[a], m.Agents[a].Start, step)
+						pb := position(m.Plans[b], m.Agents[b].Start, step)
+						if pa == pb {
+							t.Errorf("agents %d and %d are both at %v at step %d", a+1, b+1, pa, step)
+						}
+
+						qa := position(m.Plans[a], m.Agents[a].Start, step-1)
+						qb := position(m.Plans[b], m.Agents[b].Start, step-1)
+						if qa == pb && qb == pa {
+							t.Errorf("agents %d and %d swap %v and %v at step %d", a+1, b+1, qa, pa, step)
+						}
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package search
+
+import "fmt"
+
+// Capability is a set of the things an algorithm does besides finding a
+// path from one start to one goal on a grid of squares, Solve turns a maze
+// away when the algorithm can't handle what is in it
+type Capability uint
+
+const (
+	// Doors is set by the algorithms that search over the keys held too,
+	// the others only know the cells and can't solve a maze with doors
+	Doors Capability = 1 << iota
+	// OneWay is set by the algorithms that only take the moves the maze
+	// allows, the ones that assume every move works both ways can't solve
+	// a maze with one-way cells
+	OneWay
+	// Goals is set by the algorithms that stop at the nearest of several
+	// goals, the incremental ones only keep their numbers for one
+	Goals
+	// Starts is set by the algorithms that search from every start at
+	// once, the others only know one start
+	Starts
+	// Agents is set by the algorithms that plan for several agents, each
+	// start of the maze is paired with the goal of the same number
+	Agents
+	// Obstacles is set by the algorithms that search over the time steps
+	// too, so they can get out of the way of the moving obstacles
+	Obstacles
+	// Graphs is set by the algorithms that only walk the edges of the
+	// maze's Graph, so they solve the mazes loaded from graph files too.
+	// the others need the cells of a grid
+	Graphs
+	// Hexes is set by the algorithms that solve hex mazes, the ones that
+	// jump along the rows and columns of squares can't
+	Hexes
+	// Floors is set by the algorithms that take the stairs between the
+	// floors of a maze, the jumps of JPS only know one floor
+	Floors
+	// Weights is set by the algorithms that walk the cells one move at a
+	// time and add up their terrain costs, the jumps of JPS count every
+	// cell they go over as 1
+	Weights
+	// Toggles is set by the algorithms that flip the maze's Toggles while
+	// they run, every leg of a tour would flip them all over again
+	Toggles
+	// Steps is set by the algorithms that find the path with the fewest
+	// moves and not the cheapest one, the regions of their starts count
+	// the moves too
+	Steps
+)
+
+// Stepwise is what every algorithm that takes its moves one at a time from
+// Neighbors handles for free. a new kind of cell or move that Neighbors
+// knows goes in here and not in every registration
+const Stepwise = OneWay | Hexes | Floors | Weights
+
+// Algorithm is one entry of the registry, the Name is the value
+// that the web form sends in the "algorithm" field
+type Algorithm struct {
+	Name       string
+	SearchType int
+	New        func(m *Maze) Searcher
+	// Can is everything the algorithm handles
+	Can Capability
+}
+
+// Has tells if the algorithm has all of the capabilities
+func (a Algorithm) Has(c Capability) bool {
+	return a.Can&c == c
+}
+
+var algorithms []Algorithm
+
+// Register adds an algorithm to the registry, every algorithm file
+// calls it from its init function
+func Register(a Algorithm) {
+	algorithms = append(algorithms, a)
+}
+
+// Algorithms returns every registered algorithm
+func Algorithms() []Algorithm {
+	return algorithms
+}
+
+// Lookup finds a registered algorithm by its name
+func Lookup(name string) (Algorithm, bool) {
+	for _, a := range algorithms {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Algorithm{}, false
+}
+
+// Solve looks the algorithm up by name and runs it on the maze
+func Solve(name string, m *Maze) error {
+	a, ok := Lookup(name)
+	if !ok {
+		return fmt.Errorf("unknown algorithm %q", name)
+	}
+
+	if m.Network != nil && !a.Has(Graphs) {
+		return fmt.Errorf("%s only solves grid mazes, not graphs", a.Name)
+	}
+
+	if m.Hex && !a.Has(Hexes) {
+		return fmt.Errorf("%s only solves square mazes, not hex mazes", a.Name)
+	}
+
+	if m.Floors != nil && !a.Has(Floors) {
+		return fmt.Errorf("%s only solves mazes of one floor", a.Name)
+	}
+
+	if m.HasWeights() && !a.Has(Weights) {
+		return fmt.Errorf("%s can't solve mazes with weighted terrain, every cell has to cost 1", a.Name)
+	}
+
+	if m.HasDoors() && !a.Has(Doors) {
+		return fmt.Errorf("%s can't solve mazes with keys and doors", a.Name)
+	}
+
+	if m.HasOneWay() && !a.Has(OneWay) {
+		return fmt.Errorf("%s can't solve mazes with one-way cells", a.Name)
+	}
+
+	if len(m.Obstacles) > 0 && !a.Has(Obstacles) {
+		return fmt.Errorf("%s can't plan around moving obstacles", a.Name)
+	}
+
+	if a.Has(Agents) {
+		if err := m.pairAgents(); err != nil {
+			return err
+		}
+
+		m.SearchType = a.SearchType
+		a.New(m).Solve()
+
+		return nil
+	}
+
+	if len(m.Starts) > 1 && !a.Has(Starts) {
+		return fmt.Errorf("%s can't search from more than one start", a.Name)
+	}
+
+	m.SearchType = a.SearchType
+
+	if len(m.Goals) > 1 {
+		if m.GoalMode == VisitAll {
+			return m.visitAll(a)
+		}
+		if !a.Has(Goals) {
+			return fmt.Errorf("%s can't solve mazes with more than one goal, visit them all instead", a.Name)
+		}
+	}
+
+	a.New(m).Solve()
+
+	// the map of which start is closest to each cell, the sweeps don't
+	// know about keys so a maze with doors gets none
+	if len(m.Starts) > 1 && !m.HasDoors() {
+		m.regions(a.Has(Steps))
+	}
+
+	return nil
+}
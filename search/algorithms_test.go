@@ -0,0 +1,154 @@
+package search
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+)
+
+// bundledMazes are the mazes and graphs that come with the project
+func bundledMazes(t *testing.T) []string {
+	t.Helper()
+
+	var files []string
+	for _, pattern := range []string{"../maze*.txt", "../graph-*.dot", "../graph-*.gr", "../graph-*.json", "../movingai-*.map"} {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, matches...)
+	}
+	if len(files) == 0 {
+		t.Fatal("no bundled mazes")
+	}
+	return files
+}
+
+// moveRule is one of the ways the agent can move on a grid
+type moveRule struct {
+	name     string
+	diagonal bool
+	corners  CornerRule
+}
+
+var moveRules = []moveRule{
+	{"four ways", false, CutCorners},
+	{"cut corners", true, CutCorners},
+	{"no squeeze", true, NoSqueeze},
+	{"no corner cutting", true, NoCornerCutting},
+}
+
+// solveFile loads the maze with the move rule and solves it, ok is false when
+// the maze has something the algorithm doesn't do
+func solveFile(t *testing.T, file string, rule moveRule, name string) (m *Maze, ok bool) {
+	t.Helper()
+
+	m, err := LoadMaze(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Diagonal = rule.diagonal
+	m.Corners = rule.corners
+
+	if err := Solve(name, m); err != nil {
+		return m, false
+	}
+	return m, true
+}
+
+// pathCost is the cost of the solution, -1 when there is no path
+func pathCost(m *Maze) float64 {
+	if len(m.Solution.Cells) == 0 && !m.IsGoal(m.Start) {
+		return -1
+	}
+	return m.Solution.Cost
+}
+
+// checkPath walks the solution from the start and checks that the maze
+// allows every move of it with the keys picked up on the way, that it ends on
+// a goal and that its moves add up to its cost
+func checkPath(t *testing.T, m *Maze) {
+	t.Helper()
+
+	s := m.Solution
+	node := &Node{State: m.Start}
+
+	for i, c := range s.Cells {
+		var next *Node
+		for _, n := range m.Neighbors(node) {
+			if n.State == c {
+				next = n
+				break
+			}
+		}
+		if next == nil {
+			t.Fatalf("move %d from %v to %v is not allowed", i+1, node.State, c)
+		}
+		if len(s.Keys) > 0 && s.Keys[i] != next.Keys {
+			t.Errorf("move %d to %v holds keys %v, want %v", i+1, c, s.Keys[i], next.Keys)
+		}
+		node = next
+	}
+
+	if !m.IsGoal(node.State) {
+		t.Errorf("the path ends on %v, not on a goal", node.State)
+	}
+	if math.Abs(node.Cost-s.Cost) > 1e-9 {
+		t.Errorf("the moves cost %g, the solution says %g", node.Cost, s.Cost)
+	}
+}
+
+// agree solves every bundled maze with the algorithms and checks that all
+// those that can solve it measure the same path, measure gets -1 when there
+// is no path
+func agree(t *testing.T, names []string, measure func(m *Maze) float64) {
+	for _, file := range bundledMazes(t) {
+		for _, rule := range moveRules {
+			t.Run(filepath.Base(file)+"/"+rule.name, func(t *testing.T) {
+				// the first algorithm that solved the maze and its measure
+				first, want := "", 0.0
+
+				for _, name := range names {
+					m, ok := solveFile(t, file, rule, name)
+					if !ok {
+						// the maze has something the algorithm doesn't do
+						continue
+					}
+
+					got := -1.0
+					if pathCost(m) >= 0 {
+						checkPath(t, m)
+						got = measure(m)
+					}
+
+					if first == "" {
+						first, want = name, got
+						continue
+					}
+					if math.Abs(got-want) > 1e-9 {
+						t.Errorf("%s: %g, %s: %g", name, got, first, want)
+					}
+				}
+
+				if first == "" {
+					t.Skip("none of the algorithms solves the maze")
+				}
+			})
+		}
+	}
+}
+
+// the algorithms that always find the path with the fewest moves
+var fewest = []string{"BFS", "IDDFS", "BiBFS"}
+
+func TestFewestMoves(t *testing.T) {
+	agree(t, fewest, func(m *Maze) float64 { return float64(len(m.Solution.Cells)) })
+}
+
+// the algorithms that always find the cheapest path, ARA* with its last phase
+// and LPA* and D* Lite when no wall changes
+var optimal = []string{"Dijkstra", "AStar", "BiAStar", "JPS", "ARAStar", "LPAStar", "DStarLite"}
+
+func TestOptimalAgree(t *testing.T) {
+	agree(t, optimal, pathCost)
+}
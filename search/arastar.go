@@ -0,0 +1,231 @@
+package search
+
+import (
+	"fmt"
+	"math"
+)
+
+func init() {
+	Register(Algorithm{Name: "ARAStar", SearchType: ARASTAR, New: NewAnytimeRepairingAstar, Can: Stepwise | Goals | Graphs})
+}
+
+const (
+	// DefaultARAWeight is the w ARA* starts with when the maze has no Weight
+	DefaultARAWeight = 3.0
+
+	// how much w goes down after every solution
+	araWeightStep = 0.5
+)
+
+// Phase is one of the solutions an anytime search found on its way
+type Phase struct {
+	// the w the path was found with
+	Weight float64
+	// the solution costs at most Bound times the optimal cost
+	Bound    float64
+	Solution Solution
+	// how many nodes the phase expanded
+	Explored int
+}
+
+// AnytimeRepairingAstar (ARA*) runs weighted A* with a big w to get a first
+// solution fast, then lowers w and repairs the search it already has instead
+// of starting over, every pass gives a path that is at least as good. it
+// stops once a pass with w = 1 is done or the bound shows the path is optimal
+type AnytimeRepairingAstar struct {
+	Game *Maze
+
+	// the one node of every cell reached so far, Cost is the best g known
+	nodes map[Point]*Node
+	open  *PriorityFrontier
+	// cells expanded in the current pass
+	closed map[Point]bool
+	// closed cells that got cheaper during the current pass, they go back
+	// into open for the next one
+	incons map[Point]*Node
+	w      float64
+}
+
+// NewAnytimeRepairingAstar starts with the maze's Weight and goes down to 1
+func NewAnytimeRepairingAstar(m *Maze) Searcher {
+	return &AnytimeRepairingAstar{Game: m}
+}
+
+func (a *AnytimeRepairingAstar) Solve() {
+
+	if a.Game.Debug {
+		fmt.Println("Starting to solve maze using Anytime Repairing AStar Search...")
+	}
+
+	m := a.Game
+	m.NumExplored = 0
+	m.Costs = make(map[Point]float64)
+	m.Phases = nil
+
+	a.w = m.weight(DefaultARAWeight)
+
+	start := &Node{
+		State:  m.Start,
+		Parent: nil,
+		Action: "",
+	}
+
+	a.nodes = map[Point]*Node{start.State: start}
+	a.incons = make(map[Point]*Node)
+	a.reopen([]*Node{start})
+
+	for {
+		m.Label = fmt.Sprintf("ARA* w=%g searching...", a.w)
+
+		explored := m.NumExplored
+		a.improvePath()
+
+		goal, ok := a.goal()
+		if !ok {
+			// the goal can't be reached at all
+			return
+		}
+
+		phase := Phase{
+			Weight:   a.w,
+			Bound:    a.bound(goal),
+			Solution: goal.solution(),
+			Explored: m.NumExplored - explored,
+		}
+
+		if last := len(m.Phases) - 1; last >= 0 && phase.Solution.Cost >= m.Phases[last].Solution.Cost {
+			// the pass found the same path again, it only proved a
+			// better bound for it
+			phase.Weight = m.Phases[last].Weight
+			phase.Explored += m.Phases[last].Explored
+			m.Phases[last] = phase
+		} else {
+			m.Phases = append(m.Phases, phase)
+		}
+		m.Solution = phase.Solution
+
+		// one frame with the solution of the phase
+		m.Label = fmt.Sprintf("ARA* solution %d: w=%g, cost %g, bound %.2f", len(m.Phases), a.w, phase.Solution.Cost, phase.Bound)
+		m.frame()
+
+		// a bound of 1 means the path is optimal, lowering w can't help
+		if a.w <= 1 || phase.Bound <= 1 {
+			return
+		}
+
+		a.w = max(1, a.w-araWeightStep)
+
+		// next pass, every cell that is still open or got inconsistent is
+		// searched again with the new w
+		next := append([]*Node(nil), a.open.Nodes...)
+		for _, n := range a.incons {
+			next = append(next, n)
+		}
+		a.incons = make(map[Point]*Node)
+		a.reopen(next)
+	}
+}
+
+// reopen starts a new pass with the nodes as the open list
+func (a *AnytimeRepairingAstar) reopen(nodes []*Node) {
+	a.open = &PriorityFrontier{Cost: a.fvalue}
+	a.closed = make(map[Point]bool)
+	a.Game.passWeight = a.w
+
+	// the animation shows every pass on its own
+	a.Game.Explored = nil
+
+	for _, n := range nodes {
+		a.open.Add(n)
+	}
+}
+
+func (a *AnytimeRepairingAstar) fvalue(n *Node) float64 {
+	n.EstimatedCostToGoal = a.Game.Estimate(n.State)
+	return n.Cost + a.w*n.EstimatedCostToGoal
+}
+
+// improvePath expands nodes until nothing in open can beat the goal any more
+func (a *AnytimeRepairingAstar) improvePath() {
+	m := a.Game
+
+	for !a.open.Empty() {
+
+		if goal, ok := a.goal(); ok && goal.Cost <= a.open.Nodes[0].Priority {
+			return
+		}
+
+		currentNode, err := a.open.Remove()
+		if err != nil {
+			return
+		}
+
+		a.closed[currentNode.State] = true
+
+		m.CurrentNode = currentNode
+		m.NumExplored++
+		m.Costs[currentNode.State] = currentNode.Cost
+		m.Explored = append(m.Explored, currentNode.State)
+
+		// Build animation frame if appropriate.
+		m.frame()
+
+		for _, child := range m.Neighbors(currentNode) {
+			n, seen := a.nodes[child.State]
+
+			if seen && n.Cost <= child.Cost {
+				continue
+			}
+
+			if seen {
+				n.Parent = currentNode
+				n.Action = child.Action
+				n.Cost = child.Cost
+				n.Depth = child.Depth
+			} else {
+				n = child
+				a.nodes[n.State] = n
+			}
+
+			switch {
+			case a.closed[n.State]:
+				// closed cells are not expanded twice in one pass
+				a.incons[n.State] = n
+			case a.open.ContainsState(n):
+				a.open.Fix(n)
+			default:
+				a.open.Add(n)
+			}
+		}
+	}
+}
+
+// goal is the cheapest goal node reached so far
+func (a *AnytimeRepairingAstar) goal() (*Node, bool) {
+	var best *Node
+	for _, p := range a.Game.Goals {
+		if n, ok := a.nodes[p]; ok && (best == nil || n.Cost < best.Cost) {
+			best = n
+		}
+	}
+	return best, best != nil
+}
+
+// bound is how far from the optimal the goal's cost can be at most, it is
+// often a lot better than the w the pass was searched with
+func (a *AnytimeRepairingAstar) bound(goal *Node) float64 {
+	lowest := math.Inf(1)
+
+	for _, n := range a.open.Nodes {
+		lowest = min(lowest, n.Cost+a.Game.Estimate(n.State))
+	}
+	for _, n := range a.incons {
+		lowest = min(lowest, n.Cost+a.Game.Estimate(n.State))
+	}
+
+	if math.IsInf(lowest, 1) || lowest <= 0 {
+		return 1
+	}
+
+	return max(1, min(a.w, goal.Cost/lowest))
+}
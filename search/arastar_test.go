@@ -0,0 +1,61 @@
+package search
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// every phase of ARA* is cheaper than the one before and costs at most its
+// bound times the optimal cost, the last one is optimal
+func TestARAStarPhases(t *testing.T) {
+	for _, file := range bundledMazes(t) {
+		for _, rule := range moveRules {
+			t.Run(filepath.Base(file)+"/"+rule.name, func(t *testing.T) {
+				m, ok := solveFile(t, file, rule, "ARAStar")
+				if !ok {
+					t.Skip("ARA* doesn't solve the maze")
+				}
+				if pathCost(m) < 0 {
+					if len(m.Phases) > 0 {
+						t.Errorf("%d phases without a path", len(m.Phases))
+					}
+					return
+				}
+				checkPath(t, m)
+
+				d, ok := solveFile(t, file, rule, "Dijkstra")
+				if !ok {
+					t.Skip("Dijkstra doesn't solve the maze")
+				}
+				optimal := pathCost(d)
+
+				if len(m.Phases) == 0 {
+					t.Fatal("no phases")
+				}
+				for i, p := range m.Phases {
+					if p.Solution.Cost > p.Bound*optimal+1e-9 {
+						t.Errorf("phase %d costs %g, over its bound %g times %g", i+1, p.Solution.Cost, p.Bound, optimal)
+					}
+					if i == 0 {
+						continue
+					}
+					before := m.Phases[i-1]
+					if p.Solution.Cost >= before.Solution.Cost {
+						t.Errorf("phase %d costs %g, the one before %g", i+1, p.Solution.Cost, before.Solution.Cost)
+					}
+					if p.Weight >= before.Weight {
+						t.Errorf("phase %d has w=%g, the one before w=%g", i+1, p.Weight, before.Weight)
+					}
+				}
+
+				last := m.Phases[len(m.Phases)-1]
+				if last.Weight > 1 && last.Bound > 1 {
+					t.Errorf("the last phase has w=%g and bound %g", last.Weight, last.Bound)
+				}
+				if m.Solution.Cost != last.Solution.Cost {
+					t.Errorf("the solution costs %g, the last phase %g", m.Solution.Cost, last.Solution.Cost)
+				}
+			})
+		}
+	}
+}
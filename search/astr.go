@@ -0,0 +1,20 @@
+package search
+
+func init() {
+	Register(Algorithm{Name: "AStar", SearchType: ASTAR, New: NewAstrSearch, Can: Stepwise | Doors | Goals | Starts | Graphs})
+}
+
+// NewAstrSearch explores the node with the lowest f = g + h first, where g
+// is the real cost from the start and h is the estimated cost to the goal
+func NewAstrSearch(m *Maze) Searcher {
+	return &Engine{
+		Name: "AStar Search",
+		Frontier: &PriorityFrontier{
+			Cost: func(n *Node) float64 {
+				n.EstimatedCostToGoal = m.Estimate(n.State)
+				return n.Cost + n.EstimatedCostToGoal
+			},
+		},
+		Game: m,
+	}
+}
@@ -0,0 +1,14 @@
+package search
+
+func init() {
+	Register(Algorithm{Name: "BFS", SearchType: BFS, New: NewBreadthFirstSearch, Can: Stepwise | Doors | Goals | Starts | Graphs | Steps})
+}
+
+// NewBreadthFirstSearch explores the oldest node first (queue)
+func NewBreadthFirstSearch(m *Maze) Searcher {
+	return &Engine{
+		Name:     "Breadth First Search",
+		Frontier: &QueueFrontier{},
+		Game:     m,
+	}
+}
@@ -0,0 +1,307 @@
+package search
+
+import (
+	"fmt"
+	"math"
+)
+
+func init() {
+	Register(Algorithm{Name: "BiBFS", SearchType: BIBFS, New: NewBidirectionalBFS, Can: Stepwise | Goals | Graphs | Steps})
+	Register(Algorithm{Name: "BiAStar", SearchType: BIASTAR, New: NewBidirectionalAstar, Can: Stepwise | Goals | Graphs})
+}
+
+// half is one direction of a bidirectional search, the forward half starts
+// at Maze.Start and the backward half at every goal
+type half struct {
+	forward bool
+	// the best node so far for every cell this half has reached
+	reached map[Point]*Node
+	// the cells this half has expanded
+	closed   map[Point]bool
+	explored *[]Point
+	// Neighbors for the forward half, Predecessors for the backward one
+	expand func(n *Node) []*Node
+	// only the bidirectional A* keeps its nodes in a priority queue
+	frontier *PriorityFrontier
+}
+
+func newHalf(m *Maze, forward bool) *half {
+	h := &half{
+		forward: forward,
+		reached: make(map[Point]*Node),
+		closed:  make(map[Point]bool),
+	}
+
+	if forward {
+		h.explored = &m.Explored
+		h.expand = m.Neighbors
+	} else {
+		h.explored = &m.BackwardExplored
+		h.expand = m.Predecessors
+	}
+
+	return h
+}
+
+// roots are the nodes this half starts from, the start or the goals
+func (h *half) roots(m *Maze) []*Node {
+	states := []Point{m.Start}
+	if !h.forward {
+		states = m.Goals
+	}
+
+	var nodes []*Node
+	for _, state := range states {
+		n := &Node{State: state}
+		h.reached[state] = n
+		nodes = append(nodes, n)
+	}
+
+	return nodes
+}
+
+// visit marks the node as explored by this half
+func (h *half) visit(m *Maze, n *Node) {
+	h.closed[n.State] = true
+	*h.explored = append(*h.explored, n.State)
+
+	m.CurrentNode = n
+	m.NumExplored++
+
+	if h.forward {
+		m.Costs[n.State] = n.Cost
+	}
+
+	// Build animation frame if appropriate.
+	m.frame()
+}
+
+// meeting is a cell both halves have reached, with the node of each half
+type meeting struct {
+	forward  *Node
+	backward *Node
+}
+
+func (mt *meeting) cost() float64 {
+	if mt == nil {
+		return math.Inf(1)
+	}
+	return mt.forward.Cost + mt.backward.Cost
+}
+
+// meet returns the meeting at the node's cell when the other half has
+// reached it as well
+func (h *half) meet(other *half, n *Node) *meeting {
+	o, ok := other.reached[n.State]
+	if !ok {
+		return nil
+	}
+
+	if h.forward {
+		return &meeting{forward: n, backward: o}
+	}
+	return &meeting{forward: o, backward: n}
+}
+
+// join glues the forward path to the meeting cell and the backward path from
+// it to the goal into one solution
+func (g *Maze) join(mt *meeting) {
+	solution := mt.forward.solution()
+
+	// the backward half's parents lead from the meeting cell to the goal,
+	// the action of each node is the one of the move to its parent
+	for b := mt.backward; b.Parent != nil; b = b.Parent {
+		solution.Actions = append(solution.Actions, b.Action)
+		solution.Cells = append(solution.Cells, b.Parent.State)
+	}
+
+	solution.Cost = mt.cost()
+
+	meetingPoint := mt.forward.State
+	g.MeetingPoint = &meetingPoint
+	g.Solution = solution
+}
+
+func (g *Maze) resetBidirectional() {
+	g.NumExplored = 0
+	g.Costs = make(map[Point]float64)
+	g.Explored = nil
+	g.BackwardExplored = nil
+	g.MeetingPoint = nil
+}
+
+// BidirectionalBFS runs a breadth first search from the start and one from
+// the goal, one whole layer at a time, until they touch
+type BidirectionalBFS struct {
+	Game *Maze
+}
+
+func NewBidirectionalBFS(m *Maze) Searcher {
+	return &BidirectionalBFS{Game: m}
+}
+
+func (b *BidirectionalBFS) Solve() {
+
+	if b.Game.Debug {
+		fmt.Println("Starting to solve maze using Bidirectional Breadth First Search...")
+	}
+
+	m := b.Game
+	m.resetBidirectional()
+
+	forward, backward := newHalf(m, true), newHalf(m, false)
+
+	layers := map[*half][]*Node{
+		forward:  forward.roots(m),
+		backward: backward.roots(m),
+	}
+
+	if m.IsGoal(m.Start) {
+		m.join(forward.meet(backward, layers[forward][0]))
+		return
+	}
+
+	for len(layers[forward]) > 0 && len(layers[backward]) > 0 {
+
+		// grow the half with the smaller layer, it's the cheaper one
+		side, other := forward, backward
+		if len(layers[backward]) < len(layers[forward]) {
+			side, other = backward, forward
+		}
+
+		var next []*Node
+		var best *meeting
+
+		for _, node := range layers[side] {
+
+			side.visit(m, node)
+
+			for _, child := range side.expand(node) {
+				if _, ok := side.reached[child.State]; ok {
+					continue
+				}
+
+				side.reached[child.State] = child
+				next = append(next, child)
+
+				// the whole layer is finished before stopping, a later
+				// node of the layer can still meet the other half sooner
+				if mt := side.meet(other, child); mt != nil && mt.forward.Depth+mt.backward.Depth < depth(best) {
+					best = mt
+				}
+			}
+		}
+
+		if best != nil {
+			m.join(best)
+			return
+		}
+
+		layers[side] = next
+	}
+}
+
+// depth is the number of moves through the meeting cell
+func depth(mt *meeting) int {
+	if mt == nil {
+		return math.MaxInt
+	}
+	return mt.forward.Depth + mt.backward.Depth
+}
+
+// BidirectionalAstar runs an A* from the start towards the goal and one from
+// the goal towards the start, the path through the cheapest meeting cell is
+// the answer once neither frontier can beat it any more
+type BidirectionalAstar struct {
+	Game *Maze
+}
+
+func NewBidirectionalAstar(m *Maze) Searcher {
+	return &BidirectionalAstar{Game: m}
+}
+
+func (b *BidirectionalAstar) Solve() {
+
+	if b.Game.Debug {
+		fmt.Println("Starting to solve maze using Bidirectional AStar Search...")
+	}
+
+	m := b.Game
+	m.resetBidirectional()
+
+	h := m.heuristic()
+
+	forward, backward := newHalf(m, true), newHalf(m, false)
+
+	// each half estimates the way to the other half's roots
+	forward.frontier = &PriorityFrontier{
+		Cost: func(n *Node) float64 {
+			n.EstimatedCostToGoal = m.Estimate(n.State)
+			return n.Cost + n.EstimatedCostToGoal
+		},
+	}
+	backward.frontier = &PriorityFrontier{
+		Cost: func(n *Node) float64 {
+			n.EstimatedCostToGoal = h(n.State, m.Start)
+			return n.Cost + n.EstimatedCostToGoal
+		},
+	}
+
+	for _, n := range forward.roots(m) {
+		forward.frontier.Add(n)
+	}
+	for _, n := range backward.roots(m) {
+		backward.frontier.Add(n)
+	}
+
+	var best *meeting
+
+	if m.IsGoal(m.Start) {
+		best = forward.meet(backward, forward.reached[m.Start])
+	}
+
+	for !forward.frontier.Empty() && !backward.frontier.Empty() {
+
+		// the smallest f of a frontier is a lower bound for every path that
+		// still goes through it, so once the best meeting is at least as
+		// cheap as one of them nothing better can show up
+		if best.cost() <= max(forward.frontier.Nodes[0].Priority, backward.frontier.Nodes[0].Priority) {
+			break
+		}
+
+		side, other := forward, backward
+		if backward.frontier.Nodes.Len() < forward.frontier.Nodes.Len() {
+			side, other = backward, forward
+		}
+
+		node, err := side.frontier.Remove()
+		if err != nil {
+			break
+		}
+
+		side.visit(m, node)
+
+		for _, child := range side.expand(node) {
+			if side.closed[child.State] {
+				continue
+			}
+
+			if side.frontier.ContainsState(child) {
+				side.frontier.Update(child)
+			} else {
+				side.frontier.Add(child)
+				side.reached[child.State] = child
+			}
+
+			// Update changes the node that is already in the frontier, so
+			// the reached one is always the cheapest way we know
+			if mt := side.meet(other, side.reached[child.State]); mt != nil && mt.cost() < best.cost() {
+				best = mt
+			}
+		}
+	}
+
+	if best != nil {
+		m.join(best)
+	}
+}
@@ -0,0 +1,80 @@
+package search
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// on a graph the action of every move is the name of the node it goes to, the
+// moves of the backward half included, and the bidirectional searches take
+// as few moves as BFS
+func TestBidirectionalGraphActions(t *testing.T) {
+	files, err := filepath.Glob("../graph-*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	more, err := filepath.Glob("../graph-*.dot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files = append(files, more...)
+	files = append(files, "../graph-roads.gr")
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			steps := -1
+
+			for _, name := range []string{"BFS", "BiBFS", "BiAStar"} {
+				m, err := LoadMaze(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := Solve(name, m); err != nil {
+					t.Fatal(err)
+				}
+
+				s := m.Solution
+				if len(s.Cells) == 0 {
+					t.Fatalf("%s found no path", name)
+				}
+				if len(s.Actions) != len(s.Cells) {
+					t.Fatalf("%s has %d actions for %d moves", name, len(s.Actions), len(s.Cells))
+				}
+				for i, p := range s.Cells {
+					if want := m.Network.Name(p); s.Actions[i] != want {
+						t.Errorf("%s: move %d to %s is called %q", name, i+1, want, s.Actions[i])
+					}
+				}
+
+				switch {
+				case name == "BFS":
+					steps = len(s.Cells)
+				case name == "BiBFS" && len(s.Cells) != steps:
+					t.Errorf("BiBFS takes %d moves, BFS %d", len(s.Cells), steps)
+				}
+			}
+		})
+	}
+}
+
+// on a grid the actions of the backward half are the directions of the moves
+// the way the path walks them
+func TestBidirectionalGridActions(t *testing.T) {
+	for _, name := range []string{"BiBFS", "BiAStar"} {
+		m, err := LoadMaze("../maze.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := Solve(name, m); err != nil {
+			t.Fatal(err)
+		}
+
+		p := m.Start
+		for i, c := range m.Solution.Cells {
+			if want := m.action(p, c); m.Solution.Actions[i] != want {
+				t.Errorf("%s: move %v to %v is %q, want %q", name, p, c, m.Solution.Actions[i], want)
+			}
+			p = c
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package search
+
+import (
+	"container/heap"
+	"fmt"
+	"slices"
+)
+
+func init() {
+	Register(Algorithm{Name: "CBS", SearchType: CBS, New: NewConflictBasedSearch, Can: Stepwise | Agents})
+}
+
+// MaxConstraintNodes is the most nodes of the constraint tree CBS expands
+// before it gives up. agents that can never get past each other (two of them
+// swapping places in a corridor) would keep it splitting forever
+const MaxConstraintNodes = 5000
+
+// constraint keeps an agent out of a cell at a time step, or when edge is set
+// out of the move from a cell to the other one that ends at that time step
+type constraint struct {
+	agent int
+	t     int
+	cell  Point
+	edge  bool
+	from  Point
+}
+
+// constraintNode is a node of the constraint tree, a plan for every agent
+// that keeps to the constraints of the node
+type constraintNode struct {
+	constraints []constraint
+	plans       []Solution
+	// the sum of the costs of the plans
+	cost      float64
+	conflicts []conflict
+	index     int
+}
+
+// ConflictBasedSearch plans a path for every agent of the maze so no two of
+// them are ever in the same cell at the same time or swap their cells. it
+// starts with every agent on its own shortest path, then takes the first
+// conflict and tries both ways of solving it: one agent or the other has to
+// stay out of that cell at that time. the agent gets a new plan from a space
+// time A* that keeps to its constraints. the constraint tree is searched for
+// the cheapest node, so the sum of the costs of the plans is the smallest
+type ConflictBasedSearch struct {
+	Game *Maze
+
+	// the cells every low level search expanded
+	seen map[Point]bool
+}
+
+func NewConflictBasedSearch(m *Maze) Searcher {
+	return &ConflictBasedSearch{Game: m}
+}
+
+func (c *ConflictBasedSearch) Solve() {
+
+	if c.Game.Debug {
+		fmt.Println("Starting to solve maze using Conflict-Based Search...")
+	}
+
+	m := c.Game
+	m.NumExplored = 0
+	m.Explored = nil
+	m.Costs = make(map[Point]float64)
+	m.Plans = nil
+	m.Conflicts = 0
+	m.ConstraintNodes = 0
+	m.Solution = Solution{}
+
+	c.seen = make(map[Point]bool)
+
+	// every agent on its own shortest path first
+	root := &constraintNode{}
+	for i := range m.Agents {
+		plan, ok := c.plan(i, nil)
+		if !ok {
+			m.Label = fmt.Sprintf("CBS agent %d can't get to its goal", i+1)
+			return
+		}
+		root.plans = append(root.plans, plan)
+	}
+	c.price(root)
+
+	m.Conflicts = len(root.conflicts)
+
+	open := &constraintQueue{root}
+
+	for open.Len() > 0 {
+
+		n := heap.Pop(open).(*constraintNode)
+		m.ConstraintNodes++
+
+		if m.Debug {
+			fmt.Println("Constraint node cost", n.cost, "constraints", len(n.constraints), "conflicts", len(n.conflicts))
+		}
+
+		if len(n.conflicts) == 0 {
+			c.finish(n)
+			return
+		}
+
+		if m.ConstraintNodes >= MaxConstraintNodes {
+			m.Label = fmt.Sprintf("CBS gave up after %d constraint nodes", m.ConstraintNodes)
+			return
+		}
+
+		// one child for each of the two agents of the first conflict
+		first := n.conflicts[0]
+		for _, agent := range []int{first.a, first.b} {
+			start := m.Agents[agent].Start
+
+			k := constraint{agent: agent, t: first.t, cell: position(n.plans[agent], start, first.t)}
+			if first.edge {
+				k.edge = true
+				k.from = position(n.plans[agent], start, first.t-1)
+			}
+
+			child := &constraintNode{
+				constraints: append(slices.Clone(n.constraints), k),
+				plans:       slices.Clone(n.plans),
+			}
+
+			plan, ok := c.plan(agent, child.constraints)
+			if !ok {
+				continue
+			}
+
+			child.plans[agent] = plan
+			c.price(child)
+			heap.Push(open, child)
+		}
+	}
+
+	m.Label = "CBS found no plans without conflicts"
+}
+
+// plan is the space time A* for one agent that keeps to its constraints
+func (c *ConflictBasedSearch) plan(agent int, constraints []constraint) (Solution, bool) {
+	m := c.Game
+	goal := m.Agents[agent].Goal
+
+	var mine []constraint
+	s := &spaceTime{m: m, h: m.heuristic(), last: -1, goalLast: -1, seen: c.seen}
+
+	for _, k := range constraints {
+		if k.agent != agent {
+			continue
+		}
+
+		mine = append(mine, k)
+		s.last = max(s.last, k.t)
+		if !k.edge && k.cell == goal {
+			s.goalLast = max(s.goalLast, k.t)
+		}
+	}
+
+	s.blocked = func(from, to Point, t int) bool {
+		for _, k := range mine {
+			if k.t == t && k.cell == to && (!k.edge || k.from == from) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return s.plan(m.Agents[agent].Start, goal)
+}
+
+// price adds up the cost of the node's plans and finds their conflicts
+func (c *ConflictBasedSearch) price(n *constraintNode) {
+	n.cost = 0
+	for _, plan := range n.plans {
+		n.cost += plan.Cost
+	}
+	n.conflicts = conflicts(c.Game.Agents, n.plans)
+}
+
+// finish keeps the plans of the node, the Solution is all of them one after
+// the other. the animation then moves all the agents together one time step
+// at a time
+func (c *ConflictBasedSearch) finish(n *constraintNode) {
+	m := c.Game
+	m.Plans = n.plans
+
+	for _, plan := range n.plans {
+		m.Solution.Actions = append(m.Solution.Actions, plan.Actions...)
+		m.Solution.Cells = append(m.Solution.Cells, plan.Cells...)
+		m.Solution.Cost += plan.Cost
+	}
+
+	steps := m.Makespan()
+
+	for t := 0; t <= steps; t++ {
+		m.AgentsAt = nil
+		for i, a := range m.Agents {
+			m.AgentsAt = append(m.AgentsAt, position(m.Plans[i], a.Start, t))
+		}
+
+		m.Label = fmt.Sprintf("CBS time step %d/%d", t, steps)
+		m.frame()
+	}
+
+	m.AgentsAt = nil
+	m.Label = ""
+}
+
+// constraintQueue is the open list of CBS, the cheapest node comes out first
+// and on a tie the one with fewer conflicts
+type constraintQueue []*constraintNode
+
+func (q constraintQueue) Len() int { return len(q) }
+
+func (q constraintQueue) Less(i, j int) bool {
+	if q[i].cost != q[j].cost {
+		return q[i].cost < q[j].cost
+	}
+	return len(q[i].conflicts) < len(q[j].conflicts)
+}
+
+func (q constraintQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *constraintQueue) Push(x any) {
+	n := x.(*constraintNode)
+	n.index = len(*q)
+	*q = append(*q, n)
+}
+
+func (q *constraintQueue) Pop() any {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
@@ -0,0 +1,14 @@
+package search
+
+func init() {
+	Register(Algorithm{Name: "DFS", SearchType: DFS, New: NewDepthFirstSearch, Can: Stepwise | Doors | Goals | Starts | Graphs})
+}
+
+// NewDepthFirstSearch always explores the newest node first (stack)
+func NewDepthFirstSearch(m *Maze) Searcher {
+	return &Engine{
+		Name:     "Depth First Search",
+		Frontier: &StackFrontier{},
+		Game:     m,
+	}
+}
@@ -0,0 +1,111 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// CornerRule decides when a diagonal move may go past the corner of a wall,
+// the two cells next to both ends of the move are the ones that matter
+type CornerRule int
+
+const (
+	// CutCorners allows every diagonal move into an open cell
+	CutCorners CornerRule = iota
+	// NoSqueeze forbids going between two walls that touch at their
+	// corners, one wall next to the move is fine
+	NoSqueeze
+	// NoCornerCutting forbids diagonal moves that touch a wall at all
+	NoCornerCutting
+)
+
+// CornerRules are the corner rules by the name the web form and the command
+// line use
+var CornerRules = map[string]CornerRule{
+	"allow":      CutCorners,
+	"no-squeeze": NoSqueeze,
+	"forbid":     NoCornerCutting,
+}
+
+// LookupCornerRule finds a corner rule by name, the empty name is CutCorners
+func LookupCornerRule(name string) (CornerRule, error) {
+	if name == "" {
+		return CutCorners, nil
+	}
+
+	r, ok := CornerRules[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown corner rule %q", name)
+	}
+
+	return r, nil
+}
+
+// canMove tells if a move from a cell to the cell next to it (diagonals
+// included) or through a portal stays in the maze, ends on an open cell,
+// goes the right way through one-way cells and keeps the corner rule
+func (g *Maze) canMove(from, to Point) bool {
+	if !g.contains(to) || g.at(to).IsWall {
+		return false
+	}
+
+	if !g.oneWay(from, to) {
+		return false
+	}
+
+	return g.isTeleport(from, to) || g.pastCorners(from, to)
+}
+
+// pastCorners tells if the move between two cells next to each other keeps
+// the corner rule
+func (g *Maze) pastCorners(from, to Point) bool {
+	// a hex has no corners to go past, and stairs stay in their cell
+	if g.Hex || from.X == to.X || from.Y == to.Y {
+		return true
+	}
+
+	// the two cells the diagonal move goes between
+	a := g.at(Point{X: to.X, Y: from.Y, Z: from.Z}).IsWall
+	b := g.at(Point{X: from.X, Y: to.Y, Z: from.Z}).IsWall
+
+	switch g.Corners {
+	case NoSqueeze:
+		return !a || !b
+	case NoCornerCutting:
+		return !a && !b
+	}
+
+	return true
+}
+
+// StepCost is the cost of the move from a cell to the cell next to it, a
+// diagonal move costs √2 times the cost of the cell it goes into. the six
+// moves of a hex all cost the cell. going through a portal costs the
+// PortalCost and taking the stairs the stair cost of the floors
+func (g *Maze) StepCost(from, to Point) float64 {
+	if g.isTeleport(from, to) {
+		return g.PortalCost
+	}
+
+	if from.Z != to.Z {
+		return g.stairCost(min(from.Z, to.Z))
+	}
+
+	if !g.Hex && from.X != to.X && from.Y != to.Y {
+		return math.Sqrt2 * g.MoveCost(to)
+	}
+
+	return g.MoveCost(to)
+}
+
+// DiagonalMoves counts the diagonal moves of the solution
+func (s Solution) DiagonalMoves() int {
+	n := 0
+	for _, a := range s.Actions {
+		if strings.Contains(a, "-") {
+			n++
+		}
+	}
+	return n
+}
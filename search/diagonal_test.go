@@ -0,0 +1,56 @@
+package search
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestCornerRules(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		// the cost with CutCorners, NoSqueeze and NoCornerCutting, -1 when
+		// there is no path
+		costs [3]float64
+	}{
+		{"open", "A \n B\n", [3]float64{math.Sqrt2, math.Sqrt2, math.Sqrt2}},
+		{"one wall", "A#\n B\n", [3]float64{math.Sqrt2, math.Sqrt2, 2}},
+		{"two walls", "A#\n#B\n", [3]float64{math.Sqrt2, -1, -1}},
+		// through the 3 the diagonal costs 3·√2
+		{"around a weighted cell", "A  \n 3 \n  B\n", [3]float64{2 + math.Sqrt2, 2 + math.Sqrt2, 2 + math.Sqrt2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for rule, want := range tt.costs {
+				m, err := NewMaze(strings.NewReader(tt.src))
+				if err != nil {
+					t.Fatal(err)
+				}
+				m.Diagonal = true
+				m.Corners = CornerRule(rule)
+				if err := Solve("Dijkstra", m); err != nil {
+					t.Fatal(err)
+				}
+
+				if got := pathCost(m); math.Abs(got-want) > 1e-9 {
+					t.Errorf("corner rule %d: cost %g, want %g", rule, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLookupCornerRule(t *testing.T) {
+	for name, want := range map[string]CornerRule{"": CutCorners, "allow": CutCorners, "no-squeeze": NoSqueeze, "forbid": NoCornerCutting} {
+		got, err := LookupCornerRule(name)
+		if err != nil || got != want {
+			t.Errorf("%q: %v %v, want %v", name, got, err, want)
+		}
+	}
+
+	if _, err := LookupCornerRule("sideways"); err == nil {
+		t.Error("no error for an unknown rule")
+	}
+}
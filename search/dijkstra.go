@@ -0,0 +1,19 @@
+package search
+
+func init() {
+	Register(Algorithm{Name: "Dijkstra", SearchType: DIJKSTRA, New: NewDijkstraSearch, Can: Stepwise | Doors | Goals | Starts | Graphs})
+}
+
+// NewDijkstraSearch explores the node with the cheapest path from the
+// start first, the cost is the sum of the move costs along the parents
+func NewDijkstraSearch(m *Maze) Searcher {
+	return &Engine{
+		Name: "Dijkstra Search",
+		Frontier: &PriorityFrontier{
+			Cost: func(n *Node) float64 {
+				return n.Cost
+			},
+		},
+		Game: m,
+	}
+}
@@ -0,0 +1,160 @@
+package search
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	RegisterLoader(GraphLoader{Name: "DIMACS", Exts: []string{".gr"}, Load: LoadDimacs})
+}
+
+// LoadDimacs reads a DIMACS shortest path file (the road graphs of the 9th
+// DIMACS challenge), with the coordinates of the nodes from the .co file of
+// the same name next to it when there is one
+func LoadDimacs(filename string) (*Network, error) {
+	n, err := loadFile(filename, parseDimacs)
+	if err != nil {
+		return nil, err
+	}
+
+	coords := make(map[int]Coord)
+
+	co := strings.TrimSuffix(filename, ".gr") + ".co"
+	f, err := os.Open(co)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return nil, err
+	default:
+		defer f.Close()
+
+		coords, err = parseDimacsCoords(f, n.Len())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", co, err)
+		}
+	}
+
+	if err := n.finish(coords, "", ""); err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// parseDimacs reads the arcs of a DIMACS shortest path file:
+//
+//	c a comment
+//	p sp <nodes> <arcs>
+//	a <from> <to> <cost>
+//
+// the nodes are numbered from 1 and the arcs go one way. the network isn't
+// finished yet, LoadDimacs places the nodes
+func parseDimacs(r io.Reader) (*Network, error) {
+	n := &Network{}
+	nodes := -1
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || fields[0] == "c" {
+			continue
+		}
+
+		switch fields[0] {
+		case "p":
+			if nodes >= 0 {
+				return nil, fmt.Errorf("line %d: a second problem line", line)
+			}
+			if len(fields) != 4 || fields[1] != "sp" {
+				return nil, fmt.Errorf("line %d: want p sp <nodes> <arcs>", line)
+			}
+
+			count, err := strconv.Atoi(fields[2])
+			if err != nil || count < 0 {
+				return nil, fmt.Errorf("line %d: bad node count %q", line, fields[2])
+			}
+			nodes = count
+
+			for i := 1; i <= nodes; i++ {
+				n.node(strconv.Itoa(i))
+			}
+
+		case "a":
+			if nodes < 0 {
+				return nil, fmt.Errorf("line %d: an arc before the problem line", line)
+			}
+			if len(fields) != 4 {
+				return nil, fmt.Errorf("line %d: want a <from> <to> <cost>", line)
+			}
+
+			u, errU := strconv.Atoi(fields[1])
+			v, errV := strconv.Atoi(fields[2])
+			if errU != nil || errV != nil || u < 1 || u > nodes || v < 1 || v > nodes {
+				return nil, fmt.Errorf("line %d: the nodes must be numbers from 1 to %d", line, nodes)
+			}
+
+			cost, err := strconv.ParseFloat(fields[3], 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: bad cost %q", line, fields[3])
+			}
+
+			if err := n.edge(u-1, v-1, cost, true); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+
+		default:
+			return nil, fmt.Errorf("line %d: unknown line type %q", line, fields[0])
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if nodes < 0 {
+		return nil, errors.New("no problem line (p sp <nodes> <arcs>)")
+	}
+
+	return n, nil
+}
+
+// parseDimacsCoords reads the "v <node> <x> <y>" lines of a DIMACS
+// coordinates file
+func parseDimacsCoords(r io.Reader, nodes int) (map[int]Coord, error) {
+	coords := make(map[int]Coord)
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || fields[0] != "v" {
+			// the comments and the problem line
+			continue
+		}
+
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("line %d: want v <node> <x> <y>", line)
+		}
+
+		i, err := strconv.Atoi(fields[1])
+		if err != nil || i < 1 || i > nodes {
+			return nil, fmt.Errorf("line %d: the node must be a number from 1 to %d", line, nodes)
+		}
+
+		x, errX := strconv.ParseFloat(fields[2], 64)
+		y, errY := strconv.ParseFloat(fields[3], 64)
+		if errX != nil || errY != nil {
+			return nil, fmt.Errorf("line %d: the coordinates must be numbers", line)
+		}
+
+		coords[i-1] = Coord{X: x, Y: y}
+	}
+
+	return coords, scanner.Err()
+}
@@ -0,0 +1,119 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeDimacs puts the .gr file (and the .co file when there is one) in a
+// temporary directory and returns the name of the .gr file
+func writeDimacs(t *testing.T, gr, co string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	name := filepath.Join(dir, "graph.gr")
+	if err := os.WriteFile(name, []byte(gr), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if co != "" {
+		if err := os.WriteFile(filepath.Join(dir, "graph.co"), []byte(co), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return name
+}
+
+func TestLoadDimacs(t *testing.T) {
+	tests := []struct {
+		name   string
+		gr, co string
+		want   networkWant
+	}{
+		{
+			name: "arcs go one way",
+			gr:   "c a comment\np sp 3 2\na 1 2 4\na 2 3 2.5\n",
+			want: networkWant{
+				nodes: 3, edges: 2, start: "1", goal: "3",
+				costs:   map[string]float64{"1>2": 4, "2>3": 2.5},
+				missing: []string{"2>1", "3>2"},
+			},
+		},
+		{
+			name: "both ways and blank lines",
+			gr:   "p sp 2 2\n\na 1 2 1\n\na 2 1 3\n",
+			want: networkWant{
+				nodes: 2, edges: 2, start: "1", goal: "2",
+				costs: map[string]float64{"1>2": 1, "2>1": 3},
+			},
+		},
+		{
+			name: "nodes without arcs",
+			gr:   "p sp 4 1\na 1 2 1\n",
+			co:   "c the places\np aux sp co 4\nv 1 0 0\nv 2 1 0\nv 3 2 0\nv 4 3 0\n",
+			want: networkWant{
+				nodes: 4, edges: 1, start: "1", goal: "4",
+				costs: map[string]float64{"1>2": 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := LoadDimacs(writeDimacs(t, tt.gr, tt.co))
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkNetwork(t, n, tt.want)
+		})
+	}
+}
+
+func TestLoadDimacsCoords(t *testing.T) {
+	n, err := LoadDimacs(writeDimacs(t, "p sp 2 1\na 1 2 1\n", "v 1 0 300\nv 2 100 310\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := n.Coords[1]; got != (Coord{X: 100, Y: 310}) {
+		t.Errorf("node 2 is at %v, want 100,310", got)
+	}
+}
+
+func TestLoadDimacsErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		gr, co string
+		err    string
+	}{
+		{"arc before the problem line", "a 1 2 1\np sp 2 1\n", "", "line 1: an arc before the problem line"},
+		{"second problem line", "p sp 2 1\np sp 2 1\n", "", "line 2: a second problem line"},
+		{"not a shortest path problem", "p max 2 1\n", "", "want p sp <nodes> <arcs>"},
+		{"bad node count", "p sp two 1\n", "", `bad node count "two"`},
+		{"short arc", "p sp 2 1\na 1 2\n", "", "want a <from> <to> <cost>"},
+		{"node out of range", "p sp 2 1\na 1 3 1\n", "", "the nodes must be numbers from 1 to 2"},
+		{"node zero", "p sp 2 1\na 0 1 1\n", "", "the nodes must be numbers from 1 to 2"},
+		{"bad cost", "p sp 2 1\na 1 2 far\n", "", `bad cost "far"`},
+		{"negative cost", "p sp 2 1\na 1 2 -1\n", "", "must be a number >= 0"},
+		{"unknown line type", "p sp 2 1\nx 1 2\n", "", `line 2: unknown line type "x"`},
+		{"no problem line", "c only comments\n", "", "no problem line"},
+		{"one node", "p sp 1 0\n", "", "the graph has 1 nodes"},
+		{"short coordinates", "p sp 2 1\na 1 2 1\n", "v 1 0\n", "want v <node> <x> <y>"},
+		{"coordinates of a missing node", "p sp 2 1\na 1 2 1\n", "v 3 0 0\n", "the node must be a number from 1 to 2"},
+		{"coordinates that aren't numbers", "p sp 2 1\na 1 2 1\n", "v 1 x y\n", "the coordinates must be numbers"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadDimacs(writeDimacs(t, tt.gr, tt.co))
+			if err == nil {
+				t.Fatalf("no error, want %q", tt.err)
+			}
+			if !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("error %q, want %q in it", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package search
+
+import (
+	"fmt"
+)
+
+func init() {
+	Register(Algorithm{Name: "DLS", SearchType: DLS, New: NewDepthLimitedSearch, Can: Stepwise | Doors | Goals | Graphs})
+}
+
+// DepthLimitedSearch is a depth first search that never goes deeper than
+// Maze.DepthLimit moves from the start
+type DepthLimitedSearch struct {
+	Game *Maze
+}
+
+// NewDepthLimitedSearch searches up to Maze.DepthLimit moves deep, a limit
+// of 0 or less means every cell of the maze is in reach
+func NewDepthLimitedSearch(m *Maze) Searcher {
+	return &DepthLimitedSearch{Game: m}
+}
+
+func (d *DepthLimitedSearch) Solve() {
+
+	if d.Game.Debug {
+		fmt.Println("Starting to solve maze using Depth Limited Search...")
+	}
+
+	d.Game.NumExplored = 0
+	d.Game.Costs = make(map[Point]float64)
+
+	if d.Game.DepthLimit <= 0 {
+		d.Game.DepthLimit = d.Game.states()
+	}
+
+	d.search(d.Game.DepthLimit)
+	d.Game.Iterations = 1
+}
+
+// search runs one depth first pass down to limit. found tells if the goal was
+// reached and cutoff tells if some node was not expanded because of the limit,
+// when neither is true a deeper pass would not find anything either
+func (d *DepthLimitedSearch) search(limit int) (found, cutoff bool) {
+
+	start := &Node{
+		State:  d.Game.Start,
+		Parent: nil,
+		Action: "",
+	}
+
+	var frontier StackFrontier
+	frontier.Add(start)
+	d.Game.CurrentNode = start
+
+	// the smallest depth every cell was reached at in this pass, a cell is
+	// only searched again when we get to it with fewer moves, otherwise a
+	// long detour could hide the short way to the goal from the limit.
+	// with other keys it counts as another cell
+	depths := map[KeyedState]int{start.keyed(): 0}
+
+	for !frontier.Empty() {
+
+		currentNode, err := frontier.Remove()
+
+		if err != nil {
+
+			return false, cutoff
+		}
+
+		if currentNode.Depth > depths[currentNode.keyed()] {
+			// reached again with fewer moves while this one was waiting
+			continue
+		}
+
+		if d.Game.Debug {
+
+			fmt.Println("Removed", currentNode.State, "depth", currentNode.Depth)
+		}
+
+		d.Game.CurrentNode = currentNode
+		d.Game.NumExplored++
+		if cost, ok := d.Game.Costs[currentNode.State]; !ok || currentNode.Cost < cost {
+			d.Game.Costs[currentNode.State] = currentNode.Cost
+		}
+		d.Game.Explored = append(d.Game.Explored, currentNode.State)
+
+		// Have we found the solution?
+		if d.Game.IsGoal(currentNode.State) {
+			d.Game.Solution = currentNode.solution()
+			return true, cutoff
+		}
+
+		// Build animation frame if appropriate.
+		d.Game.frame()
+
+		if currentNode.Depth >= limit {
+			cutoff = true
+			continue
+		}
+
+		for _, child := range d.Game.Neighbors(currentNode) {
+			if depth, ok := depths[child.keyed()]; ok && depth <= child.Depth {
+				continue
+			}
+
+			depths[child.keyed()] = child.Depth
+			frontier.Add(child)
+		}
+	}
+
+	return false, cutoff
+}
@@ -0,0 +1,439 @@
+package search
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+func init() {
+	RegisterLoader(GraphLoader{Name: "DOT", Exts: []string{".dot", ".gv"}, Load: LoadDot})
+}
+
+// LoadDot reads a Graphviz DOT file. the "weight" of an edge is its cost (1
+// when it has none, an "edge [weight=...]" sets it for the edges after it)
+// and the "pos" of a node like "3,4" is where it is drawn. the graph
+// attributes "start" and "goal" name the nodes to go from and to, the first
+// and the last node of the file otherwise. a "graph" goes both ways, a
+// "digraph" only the way of its arrows. subgraphs are not supported
+func LoadDot(filename string) (*Network, error) {
+	return loadFile(filename, parseDot)
+}
+
+func parseDot(r io.Reader) (*Network, error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := dotTokens(string(src))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &dotParser{
+		tokens:    tokens,
+		n:         &Network{},
+		coords:    make(map[int]Coord),
+		graph:     make(map[string]string),
+		edgeAttrs: make(map[string]string),
+	}
+
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+
+	if err := p.n.finish(p.coords, p.graph["start"], p.graph["goal"]); err != nil {
+		return nil, err
+	}
+
+	return p.n, nil
+}
+
+// dotToken is a word of a DOT file: an ID, a quoted string or a sign
+type dotToken struct {
+	text   string
+	quoted bool
+	line   int
+}
+
+// is tells if the token is the sign or keyword, the keywords of DOT don't
+// care about case
+func (t dotToken) is(s string) bool {
+	return !t.quoted && strings.EqualFold(t.text, s)
+}
+
+// dotTokens splits a DOT file into its tokens and drops the comments
+func dotTokens(src string) ([]dotToken, error) {
+	var tokens []dotToken
+	line := 1
+	// a "#" line is a comment only at the start of a line
+	lineStart := true
+
+	rs := []rune(src)
+	for i := 0; i < len(rs); {
+		c := rs[i]
+
+		switch {
+		case c == '\n':
+			line++
+			lineStart = true
+			i++
+			continue
+
+		case unicode.IsSpace(c):
+			i++
+			continue
+
+		case c == '#' && lineStart, c == '/' && i+1 < len(rs) && rs[i+1] == '/':
+			for i < len(rs) && rs[i] != '\n' {
+				i++
+			}
+			continue
+
+		case c == '/' && i+1 < len(rs) && rs[i+1] == '*':
+			i += 2
+			for i+1 < len(rs) && (rs[i] != '*' || rs[i+1] != '/') {
+				if rs[i] == '\n' {
+					line++
+				}
+				i++
+			}
+			if i+1 >= len(rs) {
+				return nil, fmt.Errorf("line %d: the comment never ends", line)
+			}
+			i += 2
+			continue
+		}
+
+		lineStart = false
+		start := line
+
+		switch {
+		case c == '"':
+			var b strings.Builder
+			i++
+			for i < len(rs) && rs[i] != '"' {
+				switch {
+				case rs[i] == '\\' && i+1 < len(rs) && rs[i+1] == '"':
+					b.WriteRune('"')
+					i += 2
+				case rs[i] == '\\' && i+1 < len(rs) && rs[i+1] == '\n':
+					// a line that goes on in the next one
+					line++
+					i += 2
+				default:
+					if rs[i] == '\n' {
+						line++
+					}
+					b.WriteRune(rs[i])
+					i++
+				}
+			}
+			if i >= len(rs) {
+				return nil, fmt.Errorf("line %d: the string never ends", start)
+			}
+			i++
+			tokens = append(tokens, dotToken{text: b.String(), quoted: true, line: start})
+
+		case c == '-' && i+1 < len(rs) && (rs[i+1] == '>' || rs[i+1] == '-'):
+			tokens = append(tokens, dotToken{text: string(rs[i : i+2]), line: start})
+			i += 2
+
+		case strings.ContainsRune("{}[];,=:", c):
+			tokens = append(tokens, dotToken{text: string(c), line: start})
+			i++
+
+		case c == '<':
+			return nil, fmt.Errorf("line %d: HTML strings are not supported", start)
+
+		case isDotID(c) || c == '-':
+			j := i + 1
+			for j < len(rs) && isDotID(rs[j]) {
+				j++
+			}
+			tokens = append(tokens, dotToken{text: string(rs[i:j]), line: start})
+			i = j
+
+		default:
+			return nil, fmt.Errorf("line %d: unexpected %q", start, c)
+		}
+	}
+
+	return tokens, nil
+}
+
+func isDotID(c rune) bool {
+	return c == '_' || c == '.' || unicode.IsLetter(c) || unicode.IsDigit(c) || c > unicode.MaxASCII
+}
+
+// dotParser reads the statements of a DOT file into a network
+type dotParser struct {
+	tokens   []dotToken
+	pos      int
+	directed bool
+
+	n      *Network
+	coords map[int]Coord
+	// the attributes of the graph and the ones every edge after an
+	// "edge [...]" statement gets
+	graph     map[string]string
+	edgeAttrs map[string]string
+}
+
+// peek is the next token, an empty one at the end of the file
+func (p *dotParser) peek() dotToken {
+	if p.pos >= len(p.tokens) {
+		line := 0
+		if len(p.tokens) > 0 {
+			line = p.tokens[len(p.tokens)-1].line
+		}
+		return dotToken{line: line}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *dotParser) next() dotToken {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *dotParser) expect(s string) error {
+	if t := p.next(); !t.is(s) {
+		return p.unexpected(t, fmt.Sprintf("%q", s))
+	}
+	return nil
+}
+
+func (p *dotParser) unexpected(t dotToken, want string) error {
+	if t.text == "" && !t.quoted {
+		return fmt.Errorf("line %d: the file ends, want %s", t.line, want)
+	}
+	return fmt.Errorf("line %d: unexpected %q, want %s", t.line, t.text, want)
+}
+
+// id reads a name, a number or a quoted string
+func (p *dotParser) id() (string, error) {
+	t := p.next()
+	if t.quoted {
+		return t.text, nil
+	}
+	if t.text == "" || strings.ContainsAny(t.text[:1], "{}[];,=:") || t.text == "--" || t.text == "->" {
+		return "", p.unexpected(t, "a name")
+	}
+	return t.text, nil
+}
+
+// parse reads [strict] (graph|digraph) [name] { statements }
+func (p *dotParser) parse() error {
+	if p.peek().is("strict") {
+		p.next()
+	}
+
+	switch t := p.next(); {
+	case t.is("graph"):
+	case t.is("digraph"):
+		p.directed = true
+	default:
+		return p.unexpected(t, `"graph" or "digraph"`)
+	}
+
+	if !p.peek().is("{") {
+		if _, err := p.id(); err != nil {
+			return err
+		}
+	}
+
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+
+	for !p.peek().is("}") {
+		if p.peek().text == "" && !p.peek().quoted {
+			return p.unexpected(p.peek(), `"}"`)
+		}
+		if err := p.statement(); err != nil {
+			return err
+		}
+	}
+	p.next()
+
+	if p.pos < len(p.tokens) {
+		return p.unexpected(p.peek(), "the end of the file")
+	}
+
+	return nil
+}
+
+// statement reads one attribute, node or edge statement
+func (p *dotParser) statement() error {
+	t := p.peek()
+
+	switch {
+	case t.is(";"):
+		p.next()
+		return nil
+
+	case t.is("{"), t.is("subgraph"):
+		return fmt.Errorf("line %d: subgraphs are not supported", t.line)
+
+	case t.is("graph"), t.is("node"), t.is("edge"):
+		p.next()
+		attrs, err := p.attrs()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case t.is("graph"):
+			maps.Copy(p.graph, attrs)
+		case t.is("edge"):
+			maps.Copy(p.edgeAttrs, attrs)
+		}
+		// the node defaults have nothing a search uses
+		return nil
+	}
+
+	name, err := p.id()
+	if err != nil {
+		return err
+	}
+
+	// a graph attribute like start=a
+	if p.peek().is("=") {
+		p.next()
+		value, err := p.id()
+		if err != nil {
+			return err
+		}
+		p.graph[name] = value
+		return nil
+	}
+
+	if err := p.port(); err != nil {
+		return err
+	}
+
+	chain := []int{p.n.node(name)}
+
+	for p.peek().is("->") || p.peek().is("--") {
+		op := p.next()
+		if op.is("->") != p.directed {
+			kind := "graph"
+			if p.directed {
+				kind = "digraph"
+			}
+			return fmt.Errorf("line %d: %q in a %s", op.line, op.text, kind)
+		}
+
+		if p.peek().is("{") || p.peek().is("subgraph") {
+			return fmt.Errorf("line %d: subgraphs are not supported", p.peek().line)
+		}
+
+		to, err := p.id()
+		if err != nil {
+			return err
+		}
+		if err := p.port(); err != nil {
+			return err
+		}
+
+		chain = append(chain, p.n.node(to))
+	}
+
+	attrs := make(map[string]string)
+	if p.peek().is("[") {
+		if attrs, err = p.attrs(); err != nil {
+			return err
+		}
+	}
+
+	if len(chain) == 1 {
+		return p.place(chain[0], attrs, t.line)
+	}
+
+	cost := 1.0
+	weight, ok := attrs["weight"]
+	if !ok {
+		weight, ok = p.edgeAttrs["weight"]
+	}
+	if ok {
+		if cost, err = strconv.ParseFloat(strings.TrimSpace(weight), 64); err != nil {
+			return fmt.Errorf("line %d: bad weight %q", t.line, weight)
+		}
+	}
+
+	for i := 1; i < len(chain); i++ {
+		if err := p.n.edge(chain[i-1], chain[i], cost, p.directed); err != nil {
+			return fmt.Errorf("line %d: %w", t.line, err)
+		}
+	}
+
+	return nil
+}
+
+// port skips the ":port" after a node, the edges all go to the middle
+func (p *dotParser) port() error {
+	for p.peek().is(":") {
+		p.next()
+		if _, err := p.id(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// attrs reads one or more [name=value, ...] lists
+func (p *dotParser) attrs() (map[string]string, error) {
+	attrs := make(map[string]string)
+
+	for p.peek().is("[") {
+		p.next()
+
+		for !p.peek().is("]") {
+			name, err := p.id()
+			if err != nil {
+				return nil, err
+			}
+
+			value := "true"
+			if p.peek().is("=") {
+				p.next()
+				if value, err = p.id(); err != nil {
+					return nil, err
+				}
+			}
+			attrs[name] = value
+
+			if p.peek().is(",") || p.peek().is(";") {
+				p.next()
+			}
+		}
+		p.next()
+	}
+
+	return attrs, nil
+}
+
+// place keeps the pos of the node, "x,y" with a "!" after it when graphviz
+// must not move the node
+func (p *dotParser) place(node int, attrs map[string]string, line int) error {
+	pos, ok := attrs["pos"]
+	if !ok {
+		return nil
+	}
+
+	xs, ys, ok := strings.Cut(strings.TrimSuffix(strings.TrimSpace(pos), "!"), ",")
+	x, errX := strconv.ParseFloat(strings.TrimSpace(xs), 64)
+	y, errY := strconv.ParseFloat(strings.TrimSpace(ys), 64)
+	if !ok || errX != nil || errY != nil {
+		return fmt.Errorf("line %d: bad pos %q, want \"x,y\"", line, pos)
+	}
+
+	p.coords[node] = Coord{X: x, Y: y}
+	return nil
+}
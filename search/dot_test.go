@@ -0,0 +1,191 @@
+package search
+
+import (
+	"strings"
+	"testing"
+)
+
+// edgeCost is the cost of the edge between the two nodes of the network, ok
+// is false when there is none
+func edgeCost(n *Network, from, to string) (float64, bool) {
+	u, ok := n.Lookup(from)
+	if !ok {
+		return 0, false
+	}
+	v, ok := n.Lookup(to)
+	if !ok {
+		return 0, false
+	}
+
+	for _, e := range n.Edges(u) {
+		if e.End == v {
+			return e.Cost, true
+		}
+	}
+	return 0, false
+}
+
+// networkWant is what a test expects of a network that was read
+type networkWant struct {
+	nodes, edges int
+	start, goal  string
+	// the cost of every edge "from>to" that has to be there
+	costs map[string]float64
+	// the edges "from>to" that must not be there
+	missing []string
+}
+
+func checkNetwork(t *testing.T, n *Network, want networkWant) {
+	t.Helper()
+
+	if n.Len() != want.nodes {
+		t.Errorf("nodes = %d, want %d", n.Len(), want.nodes)
+	}
+	if n.NumEdges() != want.edges {
+		t.Errorf("edges = %d, want %d", n.NumEdges(), want.edges)
+	}
+	if got := n.Names[n.Start]; got != want.start {
+		t.Errorf("start = %q, want %q", got, want.start)
+	}
+	if got := n.Names[n.Goal]; got != want.goal {
+		t.Errorf("goal = %q, want %q", got, want.goal)
+	}
+
+	for edge, cost := range want.costs {
+		from, to, _ := strings.Cut(edge, ">")
+		got, ok := edgeCost(n, from, to)
+		if !ok {
+			t.Errorf("no edge %s", edge)
+			continue
+		}
+		if got != cost {
+			t.Errorf("edge %s costs %g, want %g", edge, got, cost)
+		}
+	}
+
+	for _, edge := range want.missing {
+		from, to, _ := strings.Cut(edge, ">")
+		if _, ok := edgeCost(n, from, to); ok {
+			t.Errorf("edge %s should not be there", edge)
+		}
+	}
+
+	if len(n.Coords) != n.Len() {
+		t.Errorf("%d coords for %d nodes", len(n.Coords), n.Len())
+	}
+}
+
+func TestParseDot(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want networkWant
+	}{
+		{
+			name: "graph with weights, places and ends",
+			src: `graph roads {
+				start=a; goal=c
+				a [pos="0,0"]; b [pos="1,0!"]; c [pos="2,1"]
+				a -- b [weight=2]
+				b -- c
+			}`,
+			want: networkWant{
+				nodes: 3, edges: 2, start: "a", goal: "c",
+				costs: map[string]float64{"a>b": 2, "b>a": 2, "b>c": 1, "c>b": 1},
+			},
+		},
+		{
+			name: "digraph chain with default weight",
+			src:  `digraph { edge [weight=3]; a -> b -> c }`,
+			want: networkWant{
+				nodes: 3, edges: 2, start: "a", goal: "c",
+				costs:   map[string]float64{"a>b": 3, "b>c": 3},
+				missing: []string{"b>a", "c>b"},
+			},
+		},
+		{
+			name: "comments, quoted names and ports",
+			src: "// a comment\n# a preprocessor line\nstrict digraph \"g\" {\n" +
+				"  \"new york\":n -> boston:s /* to the north */ [weight=\"4.5\"]\n}",
+			want: networkWant{
+				nodes: 2, edges: 1, start: "new york", goal: "boston",
+				costs:   map[string]float64{"new york>boston": 4.5},
+				missing: []string{"boston>new york"},
+			},
+		},
+		{
+			name: "unknown attributes are ignored",
+			src: `GRAPH {
+				node [shape=box]
+				graph [rankdir=LR, goal=b]
+				a [color=red, label="A"]
+				a -- b [penwidth=2; style=dashed]
+				b -- c [dir]
+			}`,
+			want: networkWant{
+				nodes: 3, edges: 2, start: "a", goal: "b",
+				costs: map[string]float64{"a>b": 1, "c>b": 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := parseDot(strings.NewReader(tt.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkNetwork(t, n, tt.want)
+		})
+	}
+}
+
+func TestParseDotPlaces(t *testing.T) {
+	n, err := parseDot(strings.NewReader(`graph { a [pos="0,0"]; b [pos="3, 4!"]; a -- b }`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, _ := n.Lookup("b")
+	if got := n.Coords[b.X]; got != (Coord{X: 3, Y: 4}) {
+		t.Errorf("b is at %v, want 3,4", got)
+	}
+}
+
+func TestParseDotErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		err  string
+	}{
+		{"no graph keyword", `a -- b`, `want "graph" or "digraph"`},
+		{"arrow in a graph", `graph { a -> b }`, `line 1: "->" in a graph`},
+		{"dashes in a digraph", "digraph {\n a -- b\n}", `line 2: "--" in a digraph`},
+		{"bad weight", `graph { a -- b [weight=far] }`, `bad weight "far"`},
+		{"negative weight", `graph { a -- b [weight=-1] }`, "must be a number >= 0"},
+		{"bad pos", `graph { a [pos="1"]; a -- b }`, `bad pos "1"`},
+		{"subgraph", `graph { subgraph s { a -- b } }`, "subgraphs are not supported"},
+		{"edge to a subgraph", `graph { a -- { b c } }`, "subgraphs are not supported"},
+		{"html string", `graph { a [label=<b>x</b>] }`, "HTML strings are not supported"},
+		{"missing brace", `graph { a -- b`, "the file ends"},
+		{"text after the graph", `graph { a -- b } c`, `unexpected "c", want the end of the file`},
+		{"string never ends", `graph { "a -- b }`, "the string never ends"},
+		{"comment never ends", `graph { a -- b /* }`, "the comment never ends"},
+		{"unknown character", `graph { a -- b @ }`, `unexpected '@'`},
+		{"unknown start", `graph { start=q; a -- b }`, `no node "q"`},
+		{"one node", `graph { a }`, "the graph has 1 nodes"},
+		{"same start and goal", `graph { start=a; goal=a; a -- b }`, `both node "a"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDot(strings.NewReader(tt.src))
+			if err == nil {
+				t.Fatalf("no error, want %q", tt.err)
+			}
+			if !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("error %q, want %q in it", err, tt.err)
+			}
+		})
+	}
+}
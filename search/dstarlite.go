@@ -0,0 +1,90 @@
+package search
+
+import "fmt"
+
+func init() {
+	Register(Algorithm{Name: "DStarLite", SearchType: DSTARLITE, New: NewDStarLite, Can: Stepwise | Toggles})
+}
+
+// DStarLite moves an agent from the start to the goal along its plan. the
+// maze's Toggles change the walls while the agent walks, after every change
+// it repairs the plan from where the agent stands. it searches backwards from
+// the goal, so the distances it already has stay right while the agent moves
+type DStarLite struct {
+	Game *Maze
+}
+
+func NewDStarLite(m *Maze) Searcher {
+	return &DStarLite{Game: m}
+}
+
+func (d *DStarLite) Solve() {
+
+	if d.Game.Debug {
+		fmt.Println("Starting to solve maze using D* Lite...")
+	}
+
+	m := d.Game
+	m.resetIncremental()
+
+	agent := m.Start
+	// where the agent was at the last replan
+	last := agent
+
+	s := newIncremental(m, m.Goal, agent, false)
+
+	m.Agent = &agent
+	m.Trail = []Point{agent}
+
+	m.Label = "D* Lite first plan"
+	s.plan()
+
+	toggles := rounds(m.Toggles)
+	moves := 0
+
+	for agent != m.Goal {
+
+		// flip the walls that change after this many moves
+		if len(toggles) > 0 && toggles[0][0].After == moves {
+
+			// the keys in the queue were computed for where the agent
+			// was, adding the distance it moved keeps them a lower bound
+			s.km += s.h(last, agent)
+			last = agent
+
+			m.Changed = s.toggle(toggles[0])
+			toggles = toggles[1:]
+
+			m.Label = fmt.Sprintf("D* Lite walls changed after move %d, replanning", moves)
+			m.frame()
+
+			s.plan()
+		}
+
+		cells, ok := s.path()
+		if !ok {
+			m.Solution = Solution{}
+			m.Label = fmt.Sprintf("D* Lite no way to the goal after %d moves", moves)
+			m.frame()
+			return
+		}
+
+		// the plan is shown as the solution while the agent walks it
+		m.Solution = m.walk(cells)
+
+		agent = cells[1]
+		moves++
+		m.Trail = append(m.Trail, agent)
+		m.Changed = nil
+		s.target = agent
+
+		m.Label = fmt.Sprintf("D* Lite move %d, planned cost %g", moves, m.Solution.Cost)
+		m.frame()
+	}
+
+	// the solution is the way the agent really went
+	m.Solution = m.walk(m.Trail)
+	m.Label = fmt.Sprintf("D* Lite reached the goal: %d moves, cost %g, %d replans", moves, m.Solution.Cost, len(m.Replans)-1)
+	m.Agent = nil
+	m.Changed = nil
+}
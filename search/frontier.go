@@ -0,0 +1,159 @@
+package search
+
+import (
+	"container/heap"
+	"errors"
+)
+
+// Frontier is the set of nodes that a search is still going to visit.
+// the order in which Remove hands nodes back is what makes the search
+// a DFS, a BFS or one of the priority based searches
+type Frontier interface {
+	Add(n *Node)
+	Remove() (*Node, error)
+	ContainsState(n *Node) bool
+	Empty() bool
+	GetFrontier() []*Node
+}
+
+// StackFrontier removes the last added node first (LIFO), used by DFS
+type StackFrontier struct {
+	Nodes []*Node
+}
+
+func (s *StackFrontier) GetFrontier() []*Node {
+	return s.Nodes
+}
+
+func (s *StackFrontier) Add(n *Node) {
+	s.Nodes = append(s.Nodes, n)
+}
+
+func (s *StackFrontier) ContainsState(n *Node) bool {
+	return containsState(s.Nodes, n)
+}
+
+func (s *StackFrontier) Empty() bool {
+	return len(s.Nodes) == 0
+}
+
+func (s *StackFrontier) Remove() (*Node, error) {
+	if len(s.Nodes) > 0 {
+		node := s.Nodes[len(s.Nodes)-1]
+		s.Nodes = s.Nodes[:len(s.Nodes)-1]
+		return node, nil
+	}
+	return nil, errors.New("frontier is empty")
+}
+
+// QueueFrontier removes the first added node first (FIFO), used by BFS
+type QueueFrontier struct {
+	Nodes []*Node
+}
+
+func (q *QueueFrontier) GetFrontier() []*Node {
+	return q.Nodes
+}
+
+func (q *QueueFrontier) Add(n *Node) {
+	q.Nodes = append(q.Nodes, n)
+}
+
+func (q *QueueFrontier) ContainsState(n *Node) bool {
+	return containsState(q.Nodes, n)
+}
+
+func (q *QueueFrontier) Empty() bool {
+	return len(q.Nodes) == 0
+}
+
+func (q *QueueFrontier) Remove() (*Node, error) {
+	if len(q.Nodes) > 0 {
+		node := q.Nodes[0]
+		q.Nodes = q.Nodes[1:]
+		return node, nil
+	}
+	return nil, errors.New("frontier is empty")
+}
+
+// Updater is implemented by the frontiers that care about path costs,
+// Update is called when a cheaper route to a node that is still waiting
+// in the frontier is found
+type Updater interface {
+	Update(n *Node)
+}
+
+// PriorityFrontier removes the node with the lowest cost first.
+// Cost is called when a node is added or updated and the result is
+// stored in the node's Priority, so the heap never has to call it again
+type PriorityFrontier struct {
+	Nodes PriorityQueue
+	Cost  func(n *Node) float64
+
+	// the nodes in the heap by their state, so we don't have to scan
+	// the whole heap to find one
+	states map[KeyedState]*Node
+}
+
+func (p *PriorityFrontier) GetFrontier() []*Node {
+	return p.Nodes
+}
+
+func (p *PriorityFrontier) Add(n *Node) {
+	if p.states == nil {
+		p.states = make(map[KeyedState]*Node)
+	}
+
+	n.Priority = p.Cost(n)
+	heap.Push(&p.Nodes, n)
+	p.states[n.keyed()] = n
+}
+
+func (p *PriorityFrontier) ContainsState(n *Node) bool {
+	_, ok := p.states[n.keyed()]
+	return ok
+}
+
+// Update moves the node with the same state as n to n's parent when n
+// is cheaper, and fixes its place in the heap (decrease-key)
+func (p *PriorityFrontier) Update(n *Node) {
+	old, ok := p.states[n.keyed()]
+	if !ok || n.Cost >= old.Cost {
+		return
+	}
+
+	old.Parent = n.Parent
+	old.Action = n.Action
+	old.Cost = n.Cost
+	old.Depth = n.Depth
+	p.Fix(old)
+}
+
+// Fix recomputes the priority of a node that is in the frontier after its
+// cost was changed, and moves it to its new place in the heap
+func (p *PriorityFrontier) Fix(n *Node) {
+	n.Priority = p.Cost(n)
+	heap.Fix(&p.Nodes, n.index)
+}
+
+func (p *PriorityFrontier) Empty() bool {
+	return len(p.Nodes) == 0
+}
+
+func (p *PriorityFrontier) Remove() (*Node, error) {
+	if len(p.Nodes) > 0 {
+		node := heap.Pop(&p.Nodes).(*Node)
+		delete(p.states, node.keyed())
+		return node, nil
+	}
+	return nil, errors.New("frontier is empty")
+}
+
+func containsState(nodes []*Node, n *Node) bool {
+	for _, x := range nodes {
+		if x.State == n.State && x.Keys == n.Keys {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,21 @@
+package search
+
+func init() {
+	Register(Algorithm{Name: "GBFS", SearchType: GBFS, New: NewGreedyBestFirstSearch, Can: Stepwise | Doors | Goals | Starts | Graphs})
+}
+
+// NewGreedyBestFirstSearch explores the node closest to the goal first
+func NewGreedyBestFirstSearch(m *Maze) Searcher {
+	return &Engine{
+		Name: "Greedy Best First Search",
+		Frontier: &PriorityFrontier{
+			// GBFS graph track cost between currentNode and the Goal node
+			// that's the difference between the DIJKSTRA and GBFS graph
+			Cost: func(n *Node) float64 {
+				n.EstimatedCostToGoal = m.Estimate(n.State)
+				return n.EstimatedCostToGoal
+			},
+		},
+		Game: m,
+	}
+}
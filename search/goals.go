@@ -0,0 +1,340 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// GoalMode is what a search does in a maze with more than one goal
+type GoalMode int
+
+const (
+	// ReachAny stops at the first goal the search gets to, an optimal
+	// search finds the closest one
+	ReachAny GoalMode = iota
+	// VisitAll goes through every goal, in the order that makes the
+	// whole tour the cheapest
+	VisitAll
+)
+
+// GoalModes are the goal modes by the name the web form and the command line
+// use
+var GoalModes = map[string]GoalMode{
+	"any": ReachAny,
+	"all": VisitAll,
+}
+
+// LookupGoalMode finds a goal mode by name, the empty name is ReachAny
+func LookupGoalMode(name string) (GoalMode, error) {
+	if name == "" {
+		return ReachAny, nil
+	}
+
+	mode, ok := GoalModes[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown goal mode %q", name)
+	}
+
+	return mode, nil
+}
+
+// MaxExactGoals is the most goals the tour is searched exactly for, the time
+// that takes doubles with every goal. with more goals a nearest neighbor tour
+// is improved with 2-opt instead, which is fast but not always the best
+const MaxExactGoals = 12
+
+// Leg is one part of a VisitAll tour, from a goal (or the start) to the next
+type Leg struct {
+	From, To Point
+	// the cost and the number of moves of the leg's path
+	Cost  float64
+	Steps int
+	// how many nodes the search of the leg expanded
+	Explored int
+}
+
+// IsGoal tells if there is a goal at p
+func (g *Maze) IsGoal(p Point) bool {
+	if len(g.Goals) == 0 {
+		return p == g.Goal
+	}
+	return slices.Contains(g.Goals, p)
+}
+
+// TourStop is the place of the goal at p in the visiting order of the tour,
+// counting from 1. ok is false when there is no tour or no goal at p
+func (g *Maze) TourStop(p Point) (int, bool) {
+	for i, leg := range g.Tour {
+		if leg.To == p {
+			return i + 1, true
+		}
+	}
+	return 0, false
+}
+
+// visitAll solves the tour through every goal. the order comes from the
+// shortest distances between the goals, then the algorithm searches every
+// leg of the tour on its own
+func (g *Maze) visitAll(a Algorithm) error {
+	if g.HasDoors() {
+		return fmt.Errorf("visiting all goals doesn't work with keys and doors")
+	}
+	if len(g.Starts) > 1 {
+		return fmt.Errorf("visiting all goals needs a single start")
+	}
+	if a.Has(Toggles) && len(g.Toggles) > 0 {
+		return fmt.Errorf("visiting all goals doesn't work with %s flipping walls, every leg would flip them again", a.Name)
+	}
+
+	start, goal, starts, goals := g.Start, g.Goal, g.Starts, g.Goals
+	defer func() {
+		g.Start, g.Goal, g.Starts, g.Goals = start, goal, starts, goals
+		g.StartTime = 0
+	}()
+
+	g.Solution = Solution{}
+	g.Tour = nil
+
+	// the start is stop 0, the goals are the others
+	stops := append([]Point{start}, goals...)
+	dist := g.distanceTable(stops)
+
+	order := tspExact
+	if len(goals) > MaxExactGoals {
+		order = tspHeuristic
+	}
+
+	tour := order(dist)
+	if tour == nil {
+		// some goal can't be reached
+		return nil
+	}
+
+	var solution Solution
+	var explored []Point
+	total := 0
+
+	from := 0
+	for i, to := range tour {
+		g.Start = stops[from]
+		g.Starts = []Point{stops[from]}
+		g.Goal = stops[to]
+		g.Goals = []Point{stops[to]}
+		// one time step a cell, the moving obstacles are where the legs
+		// so far left them
+		g.StartTime = len(solution.Cells)
+		g.Label = fmt.Sprintf("tour leg %d/%d: %d,%d to %d,%d", i+1, len(tour), g.Start.X, g.Start.Y, g.Goal.X, g.Goal.Y)
+
+		a.New(g).Solve()
+
+		if len(g.Solution.Cells) == 0 && g.Start != g.Goal {
+			// the algorithm couldn't do this leg (DLS with a short limit)
+			g.Solution = Solution{}
+			return nil
+		}
+
+		solution.Actions = append(solution.Actions, g.Solution.Actions...)
+		solution.Cells = append(solution.Cells, g.Solution.Cells...)
+		solution.Cost += g.Solution.Cost
+
+		g.Tour = append(g.Tour, Leg{
+			From:     g.Start,
+			To:       g.Goal,
+			Cost:     g.Solution.Cost,
+			Steps:    len(g.Solution.Cells),
+			Explored: g.NumExplored,
+		})
+
+		total += g.NumExplored
+		explored = append(explored, g.Explored...)
+		from = to
+	}
+
+	g.Solution = solution
+	g.NumExplored = total
+	g.Explored = explored
+	g.Label = ""
+
+	return nil
+}
+
+// distanceTable has the cheapest cost from every stop to every other one,
+// +Inf when there is no way. the costs don't have to be the same both ways,
+// one-way cells can make a detour necessary in one direction
+func (g *Maze) distanceTable(stops []Point) [][]float64 {
+	dist := make([][]float64, len(stops))
+
+	for i, from := range stops {
+		costs := g.distances(from)
+
+		dist[i] = make([]float64, len(stops))
+		for j, to := range stops {
+			c, ok := costs[to]
+			if !ok {
+				c = math.Inf(1)
+			}
+			dist[i][j] = c
+		}
+	}
+
+	return dist
+}
+
+// distances runs a Dijkstra from the cell to every cell it can reach
+func (g *Maze) distances(from Point) map[Point]float64 {
+	costs := make(map[Point]float64)
+
+	frontier := &PriorityFrontier{
+		Cost: func(n *Node) float64 {
+			return n.Cost
+		},
+	}
+	frontier.Add(&Node{State: from})
+
+	for !frontier.Empty() {
+		n, err := frontier.Remove()
+		if err != nil {
+			break
+		}
+
+		costs[n.State] = n.Cost
+
+		for _, child := range g.Neighbors(n) {
+			if _, closed := costs[child.State]; closed {
+				continue
+			}
+
+			if frontier.ContainsState(child) {
+				frontier.Update(child)
+			} else {
+				frontier.Add(child)
+			}
+		}
+	}
+
+	return costs
+}
+
+// tspExact finds the cheapest order to visit the stops 1..n-1 starting at stop
+// 0 (Held-Karp). best[set][last] is the cheapest way from the start through
+// the stops of the set that ends at last. nil when a stop can't be reached
+func tspExact(dist [][]float64) []int {
+	n := len(dist) - 1
+	full := 1<<n - 1
+
+	best := make([][]float64, full+1)
+	prev := make([][]int, full+1)
+	for set := range best {
+		best[set] = make([]float64, n)
+		prev[set] = make([]int, n)
+		for i := range best[set] {
+			best[set][i] = math.Inf(1)
+		}
+	}
+
+	for i := range n {
+		best[1<<i][i] = dist[0][i+1]
+		prev[1<<i][i] = -1
+	}
+
+	for set := 1; set <= full; set++ {
+		for last := range n {
+			if set&(1<<last) == 0 || math.IsInf(best[set][last], 1) {
+				continue
+			}
+
+			for next := range n {
+				if set&(1<<next) != 0 {
+					continue
+				}
+
+				c := best[set][last] + dist[last+1][next+1]
+				if c < best[set|1<<next][next] {
+					best[set|1<<next][next] = c
+					prev[set|1<<next][next] = last
+				}
+			}
+		}
+	}
+
+	last := -1
+	for i := range n {
+		if !math.IsInf(best[full][i], 1) && (last < 0 || best[full][i] < best[full][last]) {
+			last = i
+		}
+	}
+	if last < 0 {
+		return nil
+	}
+
+	// walk back from the last stop
+	var tour []int
+	for set := full; last >= 0; {
+		tour = append(tour, last+1)
+		set, last = set&^(1<<last), prev[set][last]
+	}
+	slices.Reverse(tour)
+
+	return tour
+}
+
+// tspHeuristic goes to the nearest stop not visited yet every time, then
+// reverses parts of the tour (2-opt) as long as that makes it cheaper
+func tspHeuristic(dist [][]float64) []int {
+	n := len(dist)
+	visited := make([]bool, n)
+	visited[0] = true
+
+	var tour []int
+	at := 0
+	for range n - 1 {
+		next := -1
+		for j := 1; j < n; j++ {
+			if !visited[j] && (next < 0 || dist[at][j] < dist[at][next]) {
+				next = j
+			}
+		}
+
+		if math.IsInf(dist[at][next], 1) {
+			return nil
+		}
+
+		visited[next] = true
+		tour = append(tour, next)
+		at = next
+	}
+
+	// with one-way cells a reversed part costs something else, so every
+	// try is priced as a whole tour
+	cost := tourCost(dist, tour)
+	for improved := true; improved; {
+		improved = false
+
+		for i := 0; i < len(tour)-1; i++ {
+			for j := i + 1; j < len(tour); j++ {
+				slices.Reverse(tour[i : j+1])
+
+				if c := tourCost(dist, tour); c < cost-epsilon {
+					cost = c
+					improved = true
+				} else {
+					slices.Reverse(tour[i : j+1])
+				}
+			}
+		}
+	}
+
+	return tour
+}
+
+// tourCost is the cost of visiting the stops in order from stop 0
+func tourCost(dist [][]float64, tour []int) float64 {
+	cost := 0.0
+	at := 0
+	for _, next := range tour {
+		cost += dist[at][next]
+		at = next
+	}
+	return cost
+}
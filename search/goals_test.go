@@ -0,0 +1,184 @@
+package search
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// cheapestTour tries every order of the stops 1..n-1
+func cheapestTour(dist [][]float64) float64 {
+	stops := make([]int, len(dist)-1)
+	for i := range stops {
+		stops[i] = i + 1
+	}
+
+	best := math.Inf(1)
+	var try func(k int)
+	try = func(k int) {
+		if k == len(stops) {
+			best = min(best, tourCost(dist, stops))
+			return
+		}
+		for i := k; i < len(stops); i++ {
+			stops[k], stops[i] = stops[i], stops[k]
+			try(k + 1)
+			stops[k], stops[i] = stops[i], stops[k]
+		}
+	}
+	try(0)
+
+	return best
+}
+
+// isTour tells if the tour visits every stop 1..n-1 once
+func isTour(tour []int, n int) bool {
+	sorted := slices.Sorted(slices.Values(tour))
+	for i, s := range sorted {
+		if s != i+1 {
+			return false
+		}
+	}
+	return len(tour) == n-1
+}
+
+func TestTours(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for range 50 {
+		n := 2 + r.Intn(6)
+		dist := make([][]float64, n)
+		for i := range dist {
+			dist[i] = make([]float64, n)
+			for j := range dist[i] {
+				if i != j {
+					// not the same both ways, like with one-way cells
+					dist[i][j] = float64(1 + r.Intn(20))
+				}
+			}
+		}
+		want := cheapestTour(dist)
+
+		exact := tspExact(dist)
+		if !isTour(exact, n) {
+			t.Fatalf("%v: exact tour %v", dist, exact)
+		}
+		if got := tourCost(dist, exact); got != want {
+			t.Errorf("%v: exact tour %v costs %g, want %g", dist, exact, got, want)
+		}
+
+		heuristic := tspHeuristic(dist)
+		if !isTour(heuristic, n) {
+			t.Fatalf("%v: heuristic tour %v", dist, heuristic)
+		}
+		if got := tourCost(dist, heuristic); got < want {
+			t.Errorf("%v: heuristic tour %v costs %g, under the cheapest %g", dist, heuristic, got, want)
+		}
+	}
+
+	unreachable := [][]float64{
+		{0, 1, math.Inf(1)},
+		{1, 0, math.Inf(1)},
+		{1, 1, 0},
+	}
+	if tour := tspExact(unreachable); tour != nil {
+		t.Errorf("exact tour %v to a stop that can't be reached", tour)
+	}
+	if tour := tspHeuristic(unreachable); tour != nil {
+		t.Errorf("heuristic tour %v to a stop that can't be reached", tour)
+	}
+}
+
+// with ReachAny the optimal searches stop at the closest goal
+func TestReachAny(t *testing.T) {
+	m, err := LoadMaze("../maze-goals.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Goals) < 2 {
+		t.Fatalf("the maze has %d goals", len(m.Goals))
+	}
+	costs := m.distances(m.Start)
+	want := math.Inf(1)
+	for _, goal := range m.Goals {
+		want = min(want, costs[goal])
+	}
+
+	for _, name := range []string{"BFS", "Dijkstra", "AStar", "BiAStar", "JPS"} {
+		m, err := LoadMaze("../maze-goals.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := Solve(name, m); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := pathCost(m); got != want {
+			t.Errorf("%s: cost %g, the closest goal costs %g", name, got, want)
+		}
+		checkPath(t, m)
+	}
+}
+
+// with VisitAll the solution goes through every goal in the cheapest order,
+// and its legs join up
+func TestVisitAll(t *testing.T) {
+	m, err := LoadMaze("../maze-goals.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stops := append([]Point{m.Start}, m.Goals...)
+	want := cheapestTour(m.distanceTable(stops))
+
+	for _, name := range []string{"BFS", "Dijkstra", "AStar", "IDAStar"} {
+		m, err := LoadMaze("../maze-goals.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		m.GoalMode = VisitAll
+		if err := Solve(name, m); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := pathCost(m); math.Abs(got-want) > 1e-9 {
+			t.Errorf("%s: the tour costs %g, want %g", name, got, want)
+		}
+		checkPath(t, m)
+		if len(m.Tour) != len(m.Goals) {
+			t.Fatalf("%s: %d legs for %d goals", name, len(m.Tour), len(m.Goals))
+		}
+
+		from, cost, steps := m.Start, 0.0, 0
+		for i, leg := range m.Tour {
+			if leg.From != from {
+				t.Errorf("%s: leg %d starts at %v, the one before ended at %v", name, i+1, leg.From, from)
+			}
+			if stop, ok := m.TourStop(leg.To); !ok || stop != i+1 {
+				t.Errorf("%s: goal %v is stop %d, want %d", name, leg.To, stop, i+1)
+			}
+			steps += leg.Steps
+			if m.Solution.Cells[steps-1] != leg.To {
+				t.Errorf("%s: move %d of the solution is not at goal %v", name, steps, leg.To)
+			}
+			from = leg.To
+			cost += leg.Cost
+		}
+		if math.Abs(cost-m.Solution.Cost) > 1e-9 || steps != len(m.Solution.Cells) {
+			t.Errorf("%s: the legs cost %g in %d moves, the solution %g in %d", name, cost, steps, m.Solution.Cost, len(m.Solution.Cells))
+		}
+	}
+}
+
+func TestLookupGoalMode(t *testing.T) {
+	for name, want := range map[string]GoalMode{"": ReachAny, "any": ReachAny, "all": VisitAll} {
+		got, err := LookupGoalMode(name)
+		if err != nil || got != want {
+			t.Errorf("%q: %v %v, want %v", name, got, err, want)
+		}
+	}
+
+	if _, err := LookupGoalMode("some"); err == nil {
+		t.Error("no error for an unknown mode")
+	}
+}
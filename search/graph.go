@@ -0,0 +1,70 @@
+package search
+
+// Graph is what the searches walk, the moves out of a state and what they
+// cost. the cells of a maze are one (walls, terrain, diagonal moves, portals
+// and one-way cells all come in here), a Network loaded from a road or
+// network graph file is the other
+type Graph interface {
+	// Edges are the moves out of p
+	Edges(p Point) []Edge
+	// InEdges are the moves into p, the backward searches walk these
+	InEdges(p Point) []Edge
+}
+
+// Edge is one move of a Graph
+type Edge struct {
+	// the other end of the move, where it goes to for Edges and where it
+	// comes from for InEdges
+	End Point
+	// the action of the move the way it is walked, from End for InEdges.
+	// a node name for a Network and a direction for the cells
+	Action string
+	Cost   float64
+}
+
+// Graph is the graph the searches walk, the maze's Network when it was
+// loaded from a graph file and its cells otherwise
+func (g *Maze) Graph() Graph {
+	if g.Network != nil {
+		return g.Network
+	}
+	return grid{m: g}
+}
+
+// grid is the Graph of the cells of a maze
+type grid struct {
+	m *Maze
+}
+
+func (gr grid) Edges(p Point) []Edge {
+	g := gr.m
+
+	var edges []Edge
+	for _, e := range g.candidates(p) {
+		if !g.canMove(p, e.End) {
+			continue
+		}
+
+		e.Cost = g.StepCost(p, e.End)
+		edges = append(edges, e)
+	}
+
+	return edges
+}
+
+func (gr grid) InEdges(p Point) []Edge {
+	g := gr.m
+
+	var edges []Edge
+	for _, e := range g.candidates(p) {
+		if !g.contains(e.End) || g.at(e.End).IsWall || !g.canMove(e.End, p) {
+			continue
+		}
+
+		e.Cost = g.StepCost(e.End, p)
+		e.Action = g.action(e.End, p)
+		edges = append(edges, e)
+	}
+
+	return edges
+}
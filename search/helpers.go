@@ -0,0 +1,28 @@
+package search
+
+import (
+	"math"
+	"slices"
+)
+
+// inExplored tells if the cell is one of the items, the floor has to be the
+// same too
+func inExplored(needle Point, items []Point) bool {
+	return slices.Contains(items, needle)
+}
+
+func abs(x int) int {
+
+	if x < 0 {
+
+		return -x
+	}
+
+	return x
+}
+
+// EuclideanDist is the straight line distance between two points
+func EuclideanDist(p, goal Point) float64 {
+	return math.Sqrt(float64(p.X-goal.X)*float64(p.X-goal.X) + float64(p.Y-goal.Y)*float64(p.Y-goal.Y))
+
+}
@@ -0,0 +1,134 @@
+package search
+
+import (
+	"fmt"
+	"math"
+)
+
+// Heuristic estimates the cost of getting from p to the goal, the informed
+// searches (A*, GBFS) use it to decide which node is explored next
+type Heuristic func(p, goal Point) float64
+
+// DefaultHeuristic is used when the maze has no Heuristic set
+var DefaultHeuristic Heuristic = Manhattan
+
+// DefaultDiagonalHeuristic is used instead of the DefaultHeuristic when the
+// maze allows diagonal moves, Manhattan would overestimate them
+var DefaultDiagonalHeuristic Heuristic = Octile
+
+// DefaultHexHeuristic is used instead of the DefaultHeuristic in a hex maze,
+// Manhattan would overestimate the moves along a diagonal row
+var DefaultHexHeuristic Heuristic = HexDistance
+
+// Heuristics are the heuristics that can be picked by name from the web
+// form and the command line, add your own here to make it selectable
+var Heuristics = map[string]Heuristic{
+	"manhattan": Manhattan,
+	"euclidean": Euclidean,
+	"chebyshev": Chebyshev,
+	"octile":    Octile,
+	"hex":       HexDistance,
+	"zero":      Zero,
+}
+
+// LookupHeuristic finds a heuristic by name, the empty name is the
+// DefaultHeuristic
+func LookupHeuristic(name string) (Heuristic, error) {
+	if name == "" {
+		return DefaultHeuristic, nil
+	}
+
+	h, ok := Heuristics[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown heuristic %q", name)
+	}
+
+	return h, nil
+}
+
+// Manhattan is the number of up/down/left/right moves between the points
+func Manhattan(p, goal Point) float64 {
+	return float64(abs(p.X-goal.X) + abs(p.Y-goal.Y))
+}
+
+// Euclidean is the straight line distance between the points
+func Euclidean(p, goal Point) float64 {
+	return EuclideanDist(p, goal)
+}
+
+// Chebyshev is the distance when a diagonal move costs the same as a
+// straight one
+func Chebyshev(p, goal Point) float64 {
+	return float64(max(abs(p.X-goal.X), abs(p.Y-goal.Y)))
+}
+
+// Octile is the distance when a diagonal move costs √2
+func Octile(p, goal Point) float64 {
+	dx, dy := abs(p.X-goal.X), abs(p.Y-goal.Y)
+	return float64(max(dx, dy)) + (math.Sqrt2-1)*float64(min(dx, dy))
+}
+
+// HexDistance is the number of moves between two hexes of a hex maze, it is
+// worked out in their axial coordinates
+func HexDistance(p, goal Point) float64 {
+	q1, r1 := Axial(p)
+	q2, r2 := Axial(goal)
+	dq, dr := q1-q2, r1-r2
+	return float64(abs(dq)+abs(dr)+abs(dq+dr)) / 2
+}
+
+// Zero knows nothing about the goal, A* with it explores exactly like Dijkstra
+func Zero(p, goal Point) float64 {
+	return 0
+}
+
+// Estimate is the heuristic value of the cell at p, it is what the
+// informed searches use for the cell. with more than one goal it is the
+// estimate for the closest one
+func (g *Maze) Estimate(p Point) float64 {
+	h := g.heuristic()
+
+	if len(g.Goals) <= 1 {
+		return h(p, g.Goal)
+	}
+
+	best := math.Inf(1)
+	for _, goal := range g.Goals {
+		best = min(best, h(p, goal))
+	}
+	return best
+}
+
+// heuristic is the maze's Heuristic or the default one for its moves when it
+// has none. in a maze with floors it counts with the stairs and in a maze
+// with portals with the shortcuts. the nodes of a Network have no place to
+// measure from, so they only get Zero
+func (g *Maze) heuristic() Heuristic {
+	if g.Network != nil {
+		return Zero
+	}
+
+	h := g.Heuristic
+
+	if h == nil && g.Hex {
+		h = DefaultHexHeuristic
+	}
+
+	if h == nil && g.Diagonal {
+		h = DefaultDiagonalHeuristic
+	}
+
+	if h == nil {
+		h = DefaultHeuristic
+	}
+
+	if g.Floors != nil {
+		h = g.acrossFloors(h)
+	}
+
+	if len(g.Portals) > 0 {
+		return g.throughPortals(h)
+	}
+
+	return h
+}
@@ -0,0 +1,72 @@
+package search
+
+// hexLine is the first line of a hex maze file. the rest of the file is read
+// like a square maze, but every odd row is half a cell to the right of the
+// rows above and below it (the "odd-r" layout), so every cell has six cells
+// around it. the cells keep their row and column, the moves and the
+// distances are worked out in axial coordinates
+const hexLine = "hex"
+
+// HexDirection is one of the six moves of a hex maze in axial coordinates
+type HexDirection struct {
+	Q, R   int
+	Action string
+}
+
+// HexDirections are the moves to the six cells around a hex, the first one
+// goes right and the others go round against the clock
+var HexDirections = []HexDirection{
+	{Q: 1, R: 0, Action: "right"},
+	{Q: 1, R: -1, Action: "up-right"},
+	{Q: 0, R: -1, Action: "up-left"},
+	{Q: -1, R: 0, Action: "left"},
+	{Q: -1, R: 1, Action: "down-left"},
+	{Q: 0, R: 1, Action: "down-right"},
+}
+
+// Axial is the axial coordinates q and r of the hex at the row and column p,
+// r is the row and q goes up by one to the right along it
+func Axial(p Point) (q, r int) {
+	return p.Y - (p.X-(p.X&1))/2, p.X
+}
+
+// FromAxial is the row and column of the hex at the axial coordinates
+func FromAxial(q, r int) Point {
+	return Point{X: r, Y: q + (r-(r&1))/2}
+}
+
+// hexCandidates are the six cells around the hex at p
+func hexCandidates(p Point) []Edge {
+	q, r := Axial(p)
+
+	candidates := make([]Edge, 0, len(HexDirections))
+	for _, d := range HexDirections {
+		end := FromAxial(q+d.Q, r+d.R)
+		end.Z = p.Z
+		candidates = append(candidates, Edge{End: end, Action: d.Action})
+	}
+	return candidates
+}
+
+// hexAction names the move from a hex to one of the six around it
+func hexAction(from, to Point) string {
+	for _, e := range hexCandidates(from) {
+		if e.End == to {
+			return e.Action
+		}
+	}
+	return ""
+}
+
+// nextTo tells if q is p or one of the cells around it, the 8 around a square
+// or the 6 around a hex, or the other end of the stairs at p
+func (g *Maze) nextTo(p, q Point) bool {
+	if p.Z != q.Z {
+		to, ok := g.stairs(p)
+		return ok && to == q
+	}
+	if g.Hex {
+		return HexDistance(p, q) <= 1
+	}
+	return abs(p.X-q.X) <= 1 && abs(p.Y-q.Y) <= 1
+}
@@ -0,0 +1,116 @@
+package search
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestAxial(t *testing.T) {
+	for x := range 6 {
+		for y := range 6 {
+			p := Point{X: x, Y: y}
+			if got := FromAxial(Axial(p)); got != p {
+				t.Errorf("%v goes to axial and back as %v", p, got)
+			}
+		}
+	}
+}
+
+func TestHexCandidates(t *testing.T) {
+	tests := []struct {
+		p    Point
+		want map[string]Point
+	}{
+		// an even row is not shifted, the rows around it are half a cell to
+		// the right
+		{Point{X: 2, Y: 2}, map[string]Point{
+			"right": {X: 2, Y: 3}, "up-right": {X: 1, Y: 2}, "up-left": {X: 1, Y: 1},
+			"left": {X: 2, Y: 1}, "down-left": {X: 3, Y: 1}, "down-right": {X: 3, Y: 2},
+		}},
+		{Point{X: 3, Y: 2}, map[string]Point{
+			"right": {X: 3, Y: 3}, "up-right": {X: 2, Y: 3}, "up-left": {X: 2, Y: 2},
+			"left": {X: 3, Y: 1}, "down-left": {X: 4, Y: 2}, "down-right": {X: 4, Y: 3},
+		}},
+	}
+
+	for _, tt := range tests {
+		candidates := hexCandidates(tt.p)
+		if len(candidates) != 6 {
+			t.Fatalf("%v has %d hexes around it", tt.p, len(candidates))
+		}
+
+		for _, e := range candidates {
+			if want := tt.want[e.Action]; e.End != want {
+				t.Errorf("%s of %v is %v, want %v", e.Action, tt.p, e.End, want)
+			}
+			if d := HexDistance(tt.p, e.End); d != 1 {
+				t.Errorf("%v is %g from %v", e.End, d, tt.p)
+			}
+			if a := hexAction(tt.p, e.End); a != e.Action {
+				t.Errorf("the move %v to %v is %q, want %q", tt.p, e.End, a, e.Action)
+			}
+		}
+	}
+}
+
+// in an open hex maze HexDistance is the number of moves to every cell
+func TestHexDistance(t *testing.T) {
+	rows := []string{"hex", "A      ", strings.Repeat(" ", 7), strings.Repeat(" ", 7), "   B   ", strings.Repeat(" ", 7), strings.Repeat(" ", 7)}
+	m, err := NewMaze(strings.NewReader(strings.Join(rows, "\n") + "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, from := range []Point{m.Start, m.Goal} {
+		costs := m.distances(from)
+		if len(costs) != m.Width*m.Height {
+			t.Fatalf("%d cells reached of %d", len(costs), m.Width*m.Height)
+		}
+		for p, c := range costs {
+			if d := HexDistance(from, p); d != c {
+				t.Errorf("%v to %v takes %g moves, HexDistance says %g", from, p, c, d)
+			}
+		}
+	}
+}
+
+func TestHexSolve(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		cost    float64
+		actions []string
+	}{
+		{"down-left of an even row", "hex\n A\nB \n", 1, []string{"down-left"}},
+		{"down-right of an even row", "hex\nA \nB \n", 1, []string{"down-right"}},
+		{"no diagonal to the right", "hex\nA \n B\n", 2, nil},
+		// around the 9 through the shifted row below
+		{"weighted hex", "hex\nA 9 B\n     \n", 5, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"Dijkstra", "AStar", "BiAStar", "IDAStar"} {
+				m, err := NewMaze(strings.NewReader(tt.src))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !m.Hex {
+					t.Fatal("not a hex maze")
+				}
+				if err := Solve(name, m); err != nil {
+					t.Fatal(err)
+				}
+
+				if got := pathCost(m); got != tt.cost {
+					t.Errorf("%s: cost %g, want %g", name, got, tt.cost)
+				}
+				checkPath(t, m)
+				if tt.actions != nil && !slices.Equal(m.Solution.Actions, tt.actions) {
+					t.Errorf("%s: actions %v, want %v", name, m.Solution.Actions, tt.actions)
+				}
+			}
+		})
+	}
+}
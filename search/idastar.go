@@ -0,0 +1,137 @@
+package search
+
+import (
+	"fmt"
+	"math"
+)
+
+func init() {
+	Register(Algorithm{Name: "IDAStar", SearchType: IDASTAR, New: NewIterativeDeepeningAstar, Can: Stepwise | Doors | Goals | Graphs})
+}
+
+// IterativeDeepeningAstar is A* without the frontier. it runs depth first
+// passes that give up on every node whose f = g + h is over a threshold, and
+// raises the threshold to the smallest f that was over it after every pass.
+// the search only keeps the current path in memory, the same cells get
+// expanded again and again instead. the explored cells and their costs are
+// only kept for the animation, they would grow with every expansion
+type IterativeDeepeningAstar struct {
+	Game *Maze
+
+	// the cells on the current path, so a pass never walks in circles. with
+	// keys and doors walking back with a new key is not a circle
+	onPath map[KeyedState]bool
+	// the threshold of the pass before, a path with every node under it was
+	// walked by that pass already
+	last float64
+}
+
+// NewIterativeDeepeningAstar uses the maze's heuristic like A* does
+func NewIterativeDeepeningAstar(m *Maze) Searcher {
+	return &IterativeDeepeningAstar{Game: m}
+}
+
+func (s *IterativeDeepeningAstar) Solve() {
+
+	if s.Game.Debug {
+		fmt.Println("Starting to solve maze using Iterative Deepening AStar Search...")
+	}
+
+	s.Game.NumExplored = 0
+	s.Game.Reexpanded = 0
+	s.Game.Costs = make(map[Point]float64)
+	s.Game.Iterations = 0
+
+	s.last = math.Inf(-1)
+
+	start := &Node{
+		State:  s.Game.Start,
+		Parent: nil,
+		Action: "",
+	}
+
+	threshold := s.Game.Estimate(start.State)
+
+	for !math.IsInf(threshold, 1) {
+
+		s.Game.Threshold = threshold
+
+		// every pass starts over, the animation shows each one on its own
+		s.Game.Explored = nil
+		s.onPath = map[KeyedState]bool{start.keyed(): true}
+		explored := s.Game.NumExplored
+
+		goal, next := s.search(start, threshold, true)
+
+		s.Game.Iterations++
+
+		if s.Game.Debug {
+
+			fmt.Println("Threshold", threshold, "explored", s.Game.NumExplored-explored)
+		}
+
+		if goal != nil {
+			s.Game.Solution = goal.solution()
+			return
+		}
+
+		s.last = threshold
+		threshold = next
+	}
+}
+
+// search is one depth first pass under the node. it returns the goal node when
+// it was reached, otherwise the smallest f that was over the threshold. walked
+// tells if the pass before got as far as the parent of the node
+func (s *IterativeDeepeningAstar) search(n *Node, threshold float64, walked bool) (*Node, float64) {
+
+	n.EstimatedCostToGoal = s.Game.Estimate(n.State)
+	f := n.Cost + n.EstimatedCostToGoal
+
+	if f > threshold {
+		return nil, f
+	}
+
+	// the thresholds only grow, so the pass before went down this same path
+	walked = walked && f <= s.last
+	if walked {
+		s.Game.Reexpanded++
+	}
+
+	s.Game.CurrentNode = n
+	s.Game.NumExplored++
+	if s.Game.Animate {
+		if cost, ok := s.Game.Costs[n.State]; !ok || n.Cost < cost {
+			s.Game.Costs[n.State] = n.Cost
+		}
+		s.Game.Explored = append(s.Game.Explored, n.State)
+	}
+
+	// Have we found the solution?
+	if s.Game.IsGoal(n.State) {
+		return n, f
+	}
+
+	// Build animation frame if appropriate.
+	s.Game.frame()
+
+	next := math.Inf(1)
+
+	for _, child := range s.Game.Neighbors(n) {
+		if s.onPath[child.keyed()] {
+			continue
+		}
+
+		s.onPath[child.keyed()] = true
+		goal, t := s.search(child, threshold, walked)
+		delete(s.onPath, child.keyed())
+
+		if goal != nil {
+			return goal, t
+		}
+
+		next = min(next, t)
+	}
+
+	return nil, next
+}
@@ -0,0 +1,69 @@
+package search
+
+import (
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// IDA* finds the cheapest path and it stops on the pass whose threshold is
+// its cost. with diagonal moves every sum of 1s and √2s is another threshold
+// and the passes take too long on the bundled mazes, so those only move four
+// ways and the diagonal moves get small rooms
+func TestIDAStarOptimal(t *testing.T) {
+	type run struct {
+		name string
+		load func() (*Maze, error)
+		rule moveRule
+	}
+
+	var runs []run
+	for _, file := range bundledMazes(t) {
+		runs = append(runs, run{filepath.Base(file), func() (*Maze, error) { return LoadMaze(file) }, moveRules[0]})
+	}
+
+	rooms := []string{
+		"#######\n#A    #\n# ### #\n#    B#\n#######\n",
+		"########\n#A #   #\n#  # # #\n#    #B#\n########\n",
+		"#####\n#A.3#\n#2#.#\n#..B#\n#####\n",
+	}
+	for i, room := range rooms {
+		for _, rule := range moveRules[1:] {
+			runs = append(runs, run{"room " + string(rune('1'+i)), func() (*Maze, error) { return NewMaze(strings.NewReader(room)) }, rule})
+		}
+	}
+
+	for _, r := range runs {
+		t.Run(r.name+"/"+r.rule.name, func(t *testing.T) {
+			costs := map[string]float64{}
+			var ida *Maze
+
+			for _, name := range []string{"Dijkstra", "IDAStar"} {
+				m, err := r.load()
+				if err != nil {
+					t.Fatal(err)
+				}
+				m.Diagonal = r.rule.diagonal
+				m.Corners = r.rule.corners
+				if err := Solve(name, m); err != nil {
+					t.Skip(err)
+				}
+				costs[name] = pathCost(m)
+				ida = m
+			}
+
+			if math.Abs(costs["IDAStar"]-costs["Dijkstra"]) > 1e-9 {
+				t.Fatalf("IDA* costs %g, Dijkstra %g", costs["IDAStar"], costs["Dijkstra"])
+			}
+			if costs["IDAStar"] < 0 {
+				return
+			}
+
+			checkPath(t, ida)
+			if math.Abs(ida.Threshold-costs["IDAStar"]) > 1e-9 {
+				t.Errorf("the last pass had threshold %g, the path costs %g", ida.Threshold, costs["IDAStar"])
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package search
+
+import (
+	"fmt"
+)
+
+func init() {
+	Register(Algorithm{Name: "IDDFS", SearchType: IDDFS, New: NewIterativeDeepeningSearch, Can: Stepwise | Doors | Goals | Graphs | Steps})
+}
+
+// IterativeDeepeningSearch runs depth limited searches with a growing limit
+// until the goal shows up, so it finds the path with the fewest moves while
+// only keeping one path worth of nodes like DFS
+type IterativeDeepeningSearch struct {
+	Game *Maze
+}
+
+// NewIterativeDeepeningSearch starts with a depth limit of 0 and grows it by one
+func NewIterativeDeepeningSearch(m *Maze) Searcher {
+	return &IterativeDeepeningSearch{Game: m}
+}
+
+func (s *IterativeDeepeningSearch) Solve() {
+
+	if s.Game.Debug {
+		fmt.Println("Starting to solve maze using Iterative Deepening Depth First Search...")
+	}
+
+	s.Game.NumExplored = 0
+	s.Game.Costs = make(map[Point]float64)
+	s.Game.Iterations = 0
+
+	dls := DepthLimitedSearch{Game: s.Game}
+
+	// no path can have more moves than the maze has states
+	for limit := 0; limit <= s.Game.states(); limit++ {
+
+		s.Game.DepthLimit = limit
+
+		// every iteration starts over, the animation shows each wave on its own
+		s.Game.Explored = nil
+
+		found, cutoff := dls.search(limit)
+
+		s.Game.Iterations++
+
+		if s.Game.Debug {
+
+			fmt.Println("Depth limit", limit, "explored", len(s.Game.Explored))
+		}
+
+		if found || !cutoff {
+			return
+		}
+	}
+}
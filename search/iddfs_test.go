@@ -0,0 +1,63 @@
+package search
+
+import "testing"
+
+// a depth limited search finds no path with fewer moves than the shortest
+// one, and IDDFS stops at the limit that is just deep enough
+func TestDepthLimit(t *testing.T) {
+	m, err := LoadMaze("../maze.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Solve("BFS", m); err != nil {
+		t.Fatal(err)
+	}
+	moves := len(m.Solution.Cells)
+	if moves == 0 {
+		t.Fatal("BFS found no path")
+	}
+
+	tests := []struct {
+		limit int
+		found bool
+	}{
+		{moves - 1, false},
+		{moves, true},
+		{moves + 10, true},
+		// every cell is in reach
+		{0, true},
+	}
+
+	for _, tt := range tests {
+		m, err := LoadMaze("../maze.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		m.DepthLimit = tt.limit
+		if err := Solve("DLS", m); err != nil {
+			t.Fatal(err)
+		}
+
+		got := len(m.Solution.Cells)
+		if (got > 0) != tt.found {
+			t.Errorf("limit %d: found a path of %d moves, want found %t", tt.limit, got, tt.found)
+		}
+		if tt.limit > 0 && got > tt.limit {
+			t.Errorf("limit %d: the path has %d moves", tt.limit, got)
+		}
+	}
+
+	m, err = LoadMaze("../maze.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Solve("IDDFS", m); err != nil {
+		t.Fatal(err)
+	}
+	if m.DepthLimit != moves {
+		t.Errorf("IDDFS stopped at depth %d, want %d", m.DepthLimit, moves)
+	}
+	if m.Iterations != moves+1 {
+		t.Errorf("IDDFS ran %d iterations, want %d", m.Iterations, moves+1)
+	}
+}
@@ -0,0 +1,321 @@
+package search
+
+import (
+	"container/heap"
+	"math"
+)
+
+// incremental is the search that LPA* and D* Lite keep between their plans.
+// every cell has a g, the distance from the root the last time the cell was
+// expanded, and an rhs, the distance that its neighbors' g say it has now.
+// a cell where the two differ is inconsistent and waits in the open queue,
+// when a wall changes only the cells around it become inconsistent, so a
+// replan only expands the part of the maze whose distances really changed
+type incremental struct {
+	m *Maze
+
+	// the search grows out of the root: the start for LPA*, the goal for
+	// D* Lite which searches backwards
+	root    Point
+	forward bool
+	// the cell the path is planned for, the goal for LPA* and the agent's
+	// cell for D* Lite
+	target Point
+
+	g    map[Point]float64
+	rhs  map[Point]float64
+	open keyQueue
+	// D* Lite adds the heuristic distance the agent moved to every key
+	// instead of computing the whole queue again
+	km float64
+	h  Heuristic
+}
+
+func newIncremental(m *Maze, root, target Point, forward bool) *incremental {
+	s := &incremental{
+		m:       m,
+		root:    root,
+		target:  target,
+		forward: forward,
+		g:       make(map[Point]float64),
+		rhs:     map[Point]float64{root: 0},
+		h:       m.heuristic(),
+	}
+
+	heap.Push(&s.open, &keyItem{state: root, key: s.key(root)})
+
+	return s
+}
+
+func (s *incremental) gOf(p Point) float64 {
+	if v, ok := s.g[p]; ok {
+		return v
+	}
+	return math.Inf(1)
+}
+
+func (s *incremental) rhsOf(p Point) float64 {
+	if v, ok := s.rhs[p]; ok {
+		return v
+	}
+	return math.Inf(1)
+}
+
+// key orders the open queue, the first part works like the f of A*
+func (s *incremental) key(p Point) key {
+	k := min(s.gOf(p), s.rhsOf(p))
+	return key{k + s.h(p, s.target) + s.km, k}
+}
+
+// edge is the cost of moving from a to the cell b next to it or through a
+// portal
+func (s *incremental) edge(a, b Point) float64 {
+	if s.m.at(a).IsWall || !s.m.canMove(a, b) {
+		return math.Inf(1)
+	}
+	return s.m.StepCost(a, b)
+}
+
+// cost is the edge between a cell p closer to the root and u, in the
+// direction the moves really go
+func (s *incremental) cost(p, u Point) float64 {
+	if s.forward {
+		return s.edge(p, u)
+	}
+	return s.edge(u, p)
+}
+
+// adjacent are the cells next to p inside the maze, walls included since a
+// wall can open up later. with diagonal moves these are the 8 cells around
+// p, a flipped wall changes the diagonal moves between them too. in a hex
+// maze they are the 6 around p. the partner of a portal counts as next to it
+func (s *incremental) adjacent(p Point) []Point {
+	var cells []Point
+	for _, c := range s.m.candidates(p) {
+		if s.m.contains(c.End) {
+			cells = append(cells, c.End)
+		}
+	}
+	return cells
+}
+
+// update computes the rhs of the cell again and puts it into the open queue
+// when it is inconsistent
+func (s *incremental) update(u Point) {
+	if u != s.root {
+		best := math.Inf(1)
+		for _, p := range s.adjacent(u) {
+			best = min(best, s.gOf(p)+s.cost(p, u))
+		}
+		s.rhs[u] = best
+	}
+
+	s.open.remove(u)
+
+	if s.gOf(u) != s.rhsOf(u) {
+		heap.Push(&s.open, &keyItem{state: u, key: s.key(u)})
+	}
+}
+
+// plan expands the inconsistent cells until the target's distance is right
+func (s *incremental) plan() {
+	m := s.m
+
+	// the animation shows the cells of every plan on their own
+	m.Explored = nil
+	expanded := 0
+
+	for s.open.Len() > 0 && (s.open.top().less(s.key(s.target)) || s.rhsOf(s.target) != s.gOf(s.target)) {
+
+		item := heap.Pop(&s.open).(*keyItem)
+		u := item.state
+
+		// the agent moved since the key was computed
+		if k := s.key(u); item.key.less(k) {
+			heap.Push(&s.open, &keyItem{state: u, key: k})
+			continue
+		}
+
+		if s.gOf(u) > s.rhsOf(u) {
+			// a shorter way to the cell was found
+			s.g[u] = s.rhsOf(u)
+		} else {
+			// the way to the cell got longer or was cut off, start over
+			// from its neighbors
+			s.g[u] = math.Inf(1)
+			s.update(u)
+		}
+
+		for _, p := range s.adjacent(u) {
+			s.update(p)
+		}
+
+		expanded++
+		m.NumExplored++
+		m.CurrentNode = &Node{State: u, Cost: s.gOf(u)}
+		m.Explored = append(m.Explored, u)
+
+		// the costs are the distance from the start, D* Lite only
+		// knows the distance to the goal
+		if s.forward {
+			if math.IsInf(s.gOf(u), 1) {
+				delete(m.Costs, u)
+			} else {
+				m.Costs[u] = s.gOf(u)
+			}
+		}
+
+		// Build animation frame if appropriate.
+		m.frame()
+	}
+
+	m.Replans = append(m.Replans, expanded)
+}
+
+// toggle flips the walls and makes the cells around them consistent again,
+// the root and the target can't change. it returns the cells it flipped
+func (s *incremental) toggle(toggles []Toggle) []Point {
+	var changed []Point
+
+	for _, t := range toggles {
+		if t.Cell == s.root || t.Cell == s.target {
+			continue
+		}
+
+		s.m.toggleWall(t.Cell)
+		changed = append(changed, t.Cell)
+
+		s.update(t.Cell)
+		for _, p := range s.adjacent(t.Cell) {
+			s.update(p)
+		}
+	}
+
+	return changed
+}
+
+// path follows the cheapest neighbors from the target back to the root, the
+// cells come in that order
+func (s *incremental) path() ([]Point, bool) {
+	if math.IsInf(s.gOf(s.target), 1) {
+		return nil, false
+	}
+
+	cells := []Point{s.target}
+
+	for p := s.target; p != s.root; {
+		best, bestCost := p, math.Inf(1)
+		for _, q := range s.adjacent(p) {
+			if c := s.gOf(q) + s.cost(q, p); c < bestCost {
+				best, bestCost = q, c
+			}
+		}
+
+		// no way on, or going in circles
+		if math.IsInf(bestCost, 1) || len(cells) > s.m.Height*s.m.Width*s.m.Levels() {
+			return nil, false
+		}
+
+		p = best
+		cells = append(cells, p)
+	}
+
+	return cells, true
+}
+
+// walk is the solution that goes through the cells in order, the first cell
+// is where it starts
+func (g *Maze) walk(cells []Point) Solution {
+	var solution Solution
+
+	for i := 1; i < len(cells); i++ {
+		solution.Actions = append(solution.Actions, g.action(cells[i-1], cells[i]))
+		solution.Cells = append(solution.Cells, cells[i])
+		solution.Cost += g.StepCost(cells[i-1], cells[i])
+	}
+
+	return solution
+}
+
+// resetIncremental clears what the incremental searches fill in
+func (g *Maze) resetIncremental() {
+	g.NumExplored = 0
+	g.Costs = make(map[Point]float64)
+	g.Explored = nil
+	g.Replans = nil
+	g.Agent = nil
+	g.Trail = nil
+	g.Changed = nil
+}
+
+// key is compared by the first value, the second one breaks the ties
+type key [2]float64
+
+// with diagonal moves the costs are sums of √2s, two ways of adding up the
+// same cost can end up a tiny bit apart
+const epsilon = 1e-9
+
+func (k key) less(o key) bool {
+	if math.Abs(k[0]-o[0]) > epsilon {
+		return k[0] < o[0]
+	}
+	return k[1] < o[1]-epsilon
+}
+
+type keyItem struct {
+	state Point
+	key   key
+	index int
+}
+
+// keyQueue is the open queue of the incremental searches, a heap of cells
+// that also knows where every cell is so it can take one out
+type keyQueue struct {
+	items []*keyItem
+	at    map[Point]*keyItem
+}
+
+func (q keyQueue) Len() int { return len(q.items) }
+
+func (q keyQueue) Less(i, j int) bool { return q.items[i].key.less(q.items[j].key) }
+
+func (q keyQueue) Swap(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.items[i].index = i
+	q.items[j].index = j
+}
+
+func (q *keyQueue) Push(x any) {
+	item := x.(*keyItem)
+	item.index = len(q.items)
+	q.items = append(q.items, item)
+
+	if q.at == nil {
+		q.at = make(map[Point]*keyItem)
+	}
+	q.at[item.state] = item
+}
+
+func (q *keyQueue) Pop() any {
+	n := len(q.items)
+
+	item := q.items[n-1]
+	q.items[n-1] = nil
+	item.index = -1
+	q.items = q.items[:n-1]
+
+	delete(q.at, item.state)
+
+	return item
+}
+
+func (q *keyQueue) top() key {
+	return q.items[0].key
+}
+
+// remove takes the cell out of the queue if it is in there
+func (q *keyQueue) remove(p Point) {
+	if item, ok := q.at[p]; ok {
+		heap.Remove(q, item.index)
+	}
+}
@@ -0,0 +1,146 @@
+package search
+
+import (
+	"math"
+	"testing"
+)
+
+// toggled loads the maze with every toggle flipped, the maze LPA* and D* Lite
+// end up with
+func toggled(t *testing.T, file string, toggles []Toggle) *Maze {
+	t.Helper()
+
+	m, err := LoadMaze(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tg := range toggles {
+		m.toggleWall(tg.Cell)
+	}
+	return m
+}
+
+// after the last round of toggles LPA* has the path Dijkstra finds in the
+// maze with all of them flipped, and a small change is repaired with fewer
+// expansions than the first plan took
+func TestLPAStarReplans(t *testing.T) {
+	tests := []struct {
+		name    string
+		toggles string
+		// the replans expand fewer cells than the first plan
+		fewer bool
+	}{
+		{"no toggles", "", false},
+		{"one side of the gap", "3,7", true},
+		{"the whole gap", "3,7 3,8", false},
+		{"closed and open again", "0:3,7 0:3,8 1:3,8", false},
+		{"a wall that opens", "3,6", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := LoadMaze("../maze-dynamic.txt")
+			if err != nil {
+				t.Fatal(err)
+			}
+			m.Toggles, err = m.ParseToggles(tt.toggles)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := Solve("LPAStar", m); err != nil {
+				t.Fatal(err)
+			}
+
+			if want := len(rounds(m.Toggles)) + 1; len(m.Replans) != want {
+				t.Fatalf("%d plans, want %d", len(m.Replans), want)
+			}
+
+			d := toggled(t, "../maze-dynamic.txt", m.Toggles)
+			if err := Solve("Dijkstra", d); err != nil {
+				t.Fatal(err)
+			}
+			if got, want := pathCost(m), pathCost(d); math.Abs(got-want) > 1e-9 {
+				t.Errorf("LPA* costs %g, Dijkstra %g", got, want)
+			}
+			if pathCost(m) >= 0 {
+				checkPath(t, m)
+			}
+
+			if tt.fewer {
+				for i, n := range m.Replans[1:] {
+					if n >= m.Replans[0] {
+						t.Errorf("replan %d expanded %d cells, the first plan %d", i+1, n, m.Replans[0])
+					}
+				}
+			}
+		})
+	}
+}
+
+// the agent of D* Lite walks from the start to the goal one move at a time
+// around the walls that close while it walks. the walk never costs less than
+// the cheapest path of the maze with every toggle flipped, and when they all
+// flip before the agent moves it is that path
+func TestDStarLiteWalks(t *testing.T) {
+	tests := []struct {
+		name    string
+		toggles string
+		// the agent gets stuck
+		stuck bool
+		// the walk costs as much as the cheapest path of the changed maze
+		cheapest bool
+	}{
+		{"no toggles", "", false, true},
+		{"gap half closed at the start", "3,7", false, true},
+		{"gap closed on the way", "4:3,7 4:3,8", true, false},
+		{"detour on the way", "4:2,7", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := LoadMaze("../maze-dynamic.txt")
+			if err != nil {
+				t.Fatal(err)
+			}
+			m.Toggles, err = m.ParseToggles(tt.toggles)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := Solve("DStarLite", m); err != nil {
+				t.Fatal(err)
+			}
+
+			got := pathCost(m)
+			if tt.stuck {
+				if got >= 0 {
+					t.Errorf("the agent got to the goal for %g", got)
+				}
+				return
+			}
+
+			d := toggled(t, "../maze-dynamic.txt", m.Toggles)
+			if err := Solve("Dijkstra", d); err != nil {
+				t.Fatal(err)
+			}
+			want := pathCost(d)
+			if got < want-1e-9 || tt.cheapest && got > want+1e-9 {
+				t.Errorf("the walk costs %g, the cheapest path %g", got, want)
+			}
+
+			if m.Trail[0] != m.Start || m.Trail[len(m.Trail)-1] != m.Goal {
+				t.Errorf("the agent walked from %v to %v", m.Trail[0], m.Trail[len(m.Trail)-1])
+			}
+			checkPath(t, m)
+
+			// the toggles here only close cells, the agent never walks
+			// into one after it closed
+			for _, tg := range m.Toggles {
+				for i, p := range m.Trail {
+					if i > tg.After && p == tg.Cell {
+						t.Errorf("the agent walked into %v at move %d, a wall since move %d", p, i, tg.After)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package search
+
+import "fmt"
+
+func init() {
+	Register(Algorithm{Name: "JPS", SearchType: JPS, New: NewJumpPointSearch, Can: Goals})
+}
+
+// JumpPointSearch is A* that skips over the long runs of open cells in a
+// uniform cost grid. instead of putting every neighbor into the frontier it
+// jumps in a straight line until something interesting happens (the goal,
+// a wall corner that opens a new way, ...) and only that jump point goes
+// into the frontier. every move counts as 1 (√2 for a diagonal one), so it
+// only solves mazes without weighted terrain
+type JumpPointSearch struct {
+	Frontier PriorityFrontier
+	Game     *Maze
+
+	// cells the jumps went over, each one only once
+	scanned map[Point]bool
+}
+
+// NewJumpPointSearch uses the maze's heuristic like A* does
+func NewJumpPointSearch(m *Maze) Searcher {
+	s := &JumpPointSearch{Game: m}
+	s.Frontier.Cost = func(n *Node) float64 {
+		n.EstimatedCostToGoal = m.Estimate(n.State)
+		return n.Cost + n.EstimatedCostToGoal
+	}
+	return s
+}
+
+func (j *JumpPointSearch) Solve() {
+
+	if j.Game.Debug {
+		fmt.Println("Starting to solve maze using Jump Point Search...")
+	}
+
+	j.Game.NumExplored = 0
+	j.Game.Costs = make(map[Point]float64)
+	j.Game.JumpPoints = nil
+	j.scanned = make(map[Point]bool)
+
+	start := Node{
+		State:  j.Game.Start,
+		Parent: nil,
+		Action: "",
+	}
+
+	j.Frontier.Add(&start)
+	j.Game.CurrentNode = &start
+	j.scan(start.State)
+
+	for !j.Frontier.Empty() {
+
+		currentNode, err := j.Frontier.Remove()
+
+		if err != nil {
+
+			if j.Game.Debug {
+				fmt.Println(err)
+			}
+			return
+		}
+
+		if j.Game.Debug {
+
+			fmt.Println("Jump point", currentNode.State)
+		}
+
+		// only the jump points are expanded, so this counts the same
+		// thing as NumExplored of A*
+		j.Game.CurrentNode = currentNode
+		j.Game.NumExplored++
+		j.Game.Costs[currentNode.State] = currentNode.Cost
+		j.Game.JumpPoints = append(j.Game.JumpPoints, currentNode.State)
+
+		// Have we found the solution?
+		if j.Game.IsGoal(currentNode.State) {
+			j.Game.Solution = j.solution(currentNode)
+			return
+		}
+
+		// Build animation frame if appropriate.
+		j.Game.frame()
+
+		for _, d := range j.directions(currentNode) {
+			p, ok := j.jump(currentNode.State, d)
+			if !ok {
+				continue
+			}
+
+			// every cell of the jump costs 1, or √2 on a diagonal jump,
+			// that is the octile distance of a straight line
+			dx, dy := abs(p.X-currentNode.State.X), abs(p.Y-currentNode.State.Y)
+
+			child := &Node{
+				State:  p,
+				Parent: currentNode,
+				Action: actionTo(currentNode.State, p),
+				Cost:   currentNode.Cost + Octile(currentNode.State, p),
+				Depth:  currentNode.Depth + max(dx, dy),
+			}
+
+			j.push(child)
+		}
+
+		// a portal is a jump point, going through it is one more way
+		if to, ok := j.Game.partner(currentNode.State); ok && j.walkable(to.X, to.Y) {
+			j.push(&Node{
+				State:  to,
+				Parent: currentNode,
+				Action: teleport,
+				Cost:   currentNode.Cost + j.Game.PortalCost,
+				Depth:  currentNode.Depth + 1,
+			})
+		}
+	}
+}
+
+// push puts the jump point into the frontier, unless it was expanded already
+func (j *JumpPointSearch) push(child *Node) {
+	if _, closed := j.Game.Costs[child.State]; closed {
+		return
+	}
+
+	if j.Frontier.ContainsState(child) {
+		j.Frontier.Update(child)
+		return
+	}
+
+	j.Frontier.Add(child)
+}
+
+// directions are the ways worth jumping from the node. the start jumps
+// everywhere, the others only go on in the direction they came from and
+// to the sides, going back can never be shorter. coming out of a portal
+// is like starting over
+func (j *JumpPointSearch) directions(n *Node) []Point {
+	if j.Game.Diagonal {
+		return j.diagonalDirections(n)
+	}
+
+	if n.Parent == nil || n.Action == teleport {
+		return []Point{{X: -1, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: -1}, {X: 0, Y: 1}}
+	}
+
+	dx, dy := sign(n.State.X-n.Parent.State.X), sign(n.State.Y-n.Parent.State.Y)
+
+	if dy != 0 {
+		// moving along the row
+		return []Point{{X: -1, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: dy}}
+	}
+
+	// moving along the column
+	return []Point{{X: 0, Y: -1}, {X: 0, Y: 1}, {X: dx, Y: 0}}
+}
+
+// jump walks from p in the direction d and returns the first jump point
+func (j *JumpPointSearch) jump(p, d Point) (Point, bool) {
+	if j.Game.Diagonal {
+		return j.diagonalJump(p, d)
+	}
+
+	for {
+		p = Point{X: p.X + d.X, Y: p.Y + d.Y}
+
+		if !j.walkable(p.X, p.Y) {
+			return Point{}, false
+		}
+
+		j.scan(p)
+
+		if j.Game.IsGoal(p) || j.isPortal(p) {
+			return p, true
+		}
+
+		if d.Y != 0 {
+			// moving along the row, a cell above or below that opens up
+			// right after a wall is a forced neighbor
+			if (j.walkable(p.X-1, p.Y) && !j.walkable(p.X-1, p.Y-d.Y)) ||
+				(j.walkable(p.X+1, p.Y) && !j.walkable(p.X+1, p.Y-d.Y)) {
+				return p, true
+			}
+		} else {
+			// moving along the column, same but for the left and right cells
+			if (j.walkable(p.X, p.Y-1) && !j.walkable(p.X-d.X, p.Y-1)) ||
+				(j.walkable(p.X, p.Y+1) && !j.walkable(p.X-d.X, p.Y+1)) {
+				return p, true
+			}
+
+			// the row moves can't turn, so every cell of a column move
+			// has to check if a jump along its row finds something
+			if _, ok := j.jump(p, Point{X: 0, Y: 1}); ok {
+				return p, true
+			}
+			if _, ok := j.jump(p, Point{X: 0, Y: -1}); ok {
+				return p, true
+			}
+		}
+	}
+}
+
+// isPortal tells if there is a portal at p that goes somewhere, a jump has to
+// stop there so the search can go through it
+func (j *JumpPointSearch) isPortal(p Point) bool {
+	_, ok := j.Game.partner(p)
+	return ok
+}
+
+func (j *JumpPointSearch) walkable(x, y int) bool {
+	return 0 <= x && x < j.Game.Height && 0 <= y && y < j.Game.Width && !j.Game.Walls[x][y].IsWall
+}
+
+// scan records a cell that a jump went over as explored
+func (j *JumpPointSearch) scan(p Point) {
+	if !j.scanned[p] {
+		j.scanned[p] = true
+		j.Game.Explored = append(j.Game.Explored, p)
+	}
+}
+
+// solution fills in the cells between the jump points, every jump is a
+// straight line so we just walk it one cell at a time
+func (j *JumpPointSearch) solution(n *Node) Solution {
+	jumps := n.solution()
+
+	var solution Solution
+	from := j.Game.Start
+
+	for i, to := range jumps.Cells {
+		if jumps.Actions[i] == teleport {
+			solution.Actions = append(solution.Actions, teleport)
+			solution.Cells = append(solution.Cells, to)
+			solution.Cost += j.Game.StepCost(from, to)
+			from = to
+			continue
+		}
+
+		d := Point{X: sign(to.X - from.X), Y: sign(to.Y - from.Y)}
+
+		for from != to {
+			next := Point{X: from.X + d.X, Y: from.Y + d.Y}
+			solution.Actions = append(solution.Actions, actionTo(from, next))
+			solution.Cells = append(solution.Cells, next)
+			solution.Cost += j.Game.StepCost(from, next)
+			from = next
+		}
+	}
+
+	return solution
+}
+
+func sign(x int) int {
+	switch {
+	case x < 0:
+		return -1
+	case x > 0:
+		return 1
+	}
+	return 0
+}
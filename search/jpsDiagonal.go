@@ -0,0 +1,153 @@
+package search
+
+// the jump point rules for mazes with diagonal moves. which cells are forced
+// neighbors depends on the corner rule, a diagonal move that may not cut a
+// corner has to go around it with two straight moves instead
+
+// diagonalDirections are the ways worth jumping from the node with diagonal
+// moves, the ones the parent couldn't have reached as cheap without the node
+func (j *JumpPointSearch) diagonalDirections(n *Node) []Point {
+	if n.Parent == nil || n.Action == teleport {
+		return []Point{
+			{X: -1, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: -1}, {X: 0, Y: 1},
+			{X: -1, Y: -1}, {X: -1, Y: 1}, {X: 1, Y: -1}, {X: 1, Y: 1},
+		}
+	}
+
+	x, y := n.State.X, n.State.Y
+	dx, dy := sign(x-n.Parent.State.X), sign(y-n.Parent.State.Y)
+	w := j.walkable
+
+	var dirs []Point
+	add := func(ok bool, d Point) {
+		if ok {
+			dirs = append(dirs, d)
+		}
+	}
+
+	switch j.Game.Corners {
+
+	case CutCorners:
+		if dx != 0 && dy != 0 {
+			add(w(x, y+dy), Point{X: 0, Y: dy})
+			add(w(x+dx, y), Point{X: dx, Y: 0})
+			add(w(x+dx, y+dy), Point{X: dx, Y: dy})
+			add(!w(x-dx, y), Point{X: -dx, Y: dy})
+			add(!w(x, y-dy), Point{X: dx, Y: -dy})
+		} else if dx == 0 {
+			add(w(x, y+dy), Point{X: 0, Y: dy})
+			add(!w(x+1, y), Point{X: 1, Y: dy})
+			add(!w(x-1, y), Point{X: -1, Y: dy})
+		} else {
+			add(w(x+dx, y), Point{X: dx, Y: 0})
+			add(!w(x, y+1), Point{X: dx, Y: 1})
+			add(!w(x, y-1), Point{X: dx, Y: -1})
+		}
+
+	case NoSqueeze:
+		if dx != 0 && dy != 0 {
+			add(w(x, y+dy), Point{X: 0, Y: dy})
+			add(w(x+dx, y), Point{X: dx, Y: 0})
+			add(w(x, y+dy) || w(x+dx, y), Point{X: dx, Y: dy})
+			add(!w(x-dx, y) && w(x, y+dy), Point{X: -dx, Y: dy})
+			add(!w(x, y-dy) && w(x+dx, y), Point{X: dx, Y: -dy})
+		} else if dx == 0 {
+			if w(x, y+dy) {
+				dirs = append(dirs, Point{X: 0, Y: dy})
+				add(!w(x+1, y), Point{X: 1, Y: dy})
+				add(!w(x-1, y), Point{X: -1, Y: dy})
+			}
+		} else {
+			if w(x+dx, y) {
+				dirs = append(dirs, Point{X: dx, Y: 0})
+				add(!w(x, y+1), Point{X: dx, Y: 1})
+				add(!w(x, y-1), Point{X: dx, Y: -1})
+			}
+		}
+
+	case NoCornerCutting:
+		if dx != 0 && dy != 0 {
+			add(w(x, y+dy), Point{X: 0, Y: dy})
+			add(w(x+dx, y), Point{X: dx, Y: 0})
+			add(w(x, y+dy) && w(x+dx, y), Point{X: dx, Y: dy})
+		} else if dx == 0 {
+			// the cells to the sides are where a wall could have ended,
+			// from there the diagonal moves past it are allowed again
+			next := w(x, y+dy)
+			add(next, Point{X: 0, Y: dy})
+			add(next && w(x+1, y), Point{X: 1, Y: dy})
+			add(next && w(x-1, y), Point{X: -1, Y: dy})
+			add(w(x+1, y), Point{X: 1, Y: 0})
+			add(w(x-1, y), Point{X: -1, Y: 0})
+		} else {
+			next := w(x+dx, y)
+			add(next, Point{X: dx, Y: 0})
+			add(next && w(x, y+1), Point{X: dx, Y: 1})
+			add(next && w(x, y-1), Point{X: dx, Y: -1})
+			add(w(x, y+1), Point{X: 0, Y: 1})
+			add(w(x, y-1), Point{X: 0, Y: -1})
+		}
+	}
+
+	return dirs
+}
+
+// diagonalJump walks from p in the direction d, straight or diagonal, and
+// returns the first jump point
+func (j *JumpPointSearch) diagonalJump(p, d Point) (Point, bool) {
+	for {
+		next := Point{X: p.X + d.X, Y: p.Y + d.Y}
+
+		// the corner rule is checked for every step of a diagonal jump
+		if !j.Game.canMove(p, next) {
+			return Point{}, false
+		}
+
+		p = next
+		j.scan(p)
+
+		if j.Game.IsGoal(p) || j.isPortal(p) || j.forced(p, d) {
+			return p, true
+		}
+
+		// a diagonal jump stops where one of its straight parts finds
+		// something
+		if d.X != 0 && d.Y != 0 {
+			if _, ok := j.diagonalJump(p, Point{X: d.X, Y: 0}); ok {
+				return p, true
+			}
+			if _, ok := j.diagonalJump(p, Point{X: 0, Y: d.Y}); ok {
+				return p, true
+			}
+		}
+	}
+}
+
+// forced tells if a jump in the direction d has to stop at p because a wall
+// next to it opens a way that is only short through p
+func (j *JumpPointSearch) forced(p, d Point) bool {
+	x, y := p.X, p.Y
+	dx, dy := d.X, d.Y
+	w := j.walkable
+
+	if j.Game.Corners == NoCornerCutting {
+		switch {
+		case dx != 0 && dy != 0:
+			// only the straight parts of the jump can find something
+			return false
+		case dx != 0:
+			return (w(x, y-1) && !w(x-dx, y-1)) || (w(x, y+1) && !w(x-dx, y+1))
+		default:
+			return (w(x-1, y) && !w(x-1, y-dy)) || (w(x+1, y) && !w(x+1, y-dy))
+		}
+	}
+
+	switch {
+	case dx != 0 && dy != 0:
+		return (w(x-dx, y+dy) && !w(x-dx, y)) || (w(x+dx, y-dy) && !w(x, y-dy))
+	case dx != 0:
+		return (w(x+dx, y+1) && !w(x, y+1)) || (w(x+dx, y-1) && !w(x, y-1))
+	default:
+		return (w(x+1, y+dy) && !w(x+1, y)) || (w(x-1, y+dy) && !w(x-1, y))
+	}
+}
@@ -0,0 +1,70 @@
+package search
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJumpPointSearchRefuses(t *testing.T) {
+	tests := []struct {
+		file string
+		err  string
+	}{
+		{"../maze-weighted.txt", "can't solve mazes with weighted terrain"},
+		{"../maze-keys.txt", "can't solve mazes with keys and doors"},
+		{"../maze-oneway.txt", "can't solve mazes with one-way cells"},
+		{"../maze-hex.txt", "only solves square mazes"},
+		{"../maze-floors.txt", "only solves mazes of one floor"},
+		{"../graph-network.dot", "only solves grid mazes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			m, err := LoadMaze(tt.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = Solve("JPS", m)
+			if err == nil {
+				t.Fatalf("no error, want %q", tt.err)
+			}
+			if !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("error %q, want %q in it", err, tt.err)
+			}
+		})
+	}
+}
+
+// in an open room JPS only expands the few jump points where the way turns,
+// A* expands most of the cells on the way
+func TestJumpPointSearchJumps(t *testing.T) {
+	room := "" +
+		"##########################\n" +
+		"#A                       #\n" +
+		"#                        #\n" +
+		"#           #            #\n" +
+		"#           #            #\n" +
+		"#           #            #\n" +
+		"#           #           B#\n" +
+		"##########################\n"
+
+	for _, diagonal := range []bool{false, true} {
+		explored := map[string]int{}
+		for _, name := range []string{"AStar", "JPS"} {
+			m, err := NewMaze(strings.NewReader(room))
+			if err != nil {
+				t.Fatal(err)
+			}
+			m.Diagonal = diagonal
+			if err := Solve(name, m); err != nil {
+				t.Fatal(err)
+			}
+			checkPath(t, m)
+			explored[name] = m.NumExplored
+		}
+
+		if explored["JPS"] >= explored["AStar"] {
+			t.Errorf("diagonal %t: JPS expanded %d cells, A* %d", diagonal, explored["JPS"], explored["AStar"])
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+)
+
+func init() {
+	RegisterLoader(GraphLoader{Name: "JSON", Exts: []string{".json"}, Load: LoadJSONGraph})
+}
+
+// jsonGraph is the schema of a JSON graph file, an adjacency list:
+//
+//	{
+//	  "directed": false,
+//	  "start": "home", "goal": "work",
+//	  "nodes": [{"id": "home", "x": 0, "y": 0}, ...],
+//	  "adjacency": {"home": [{"to": "shop", "cost": 2.5}, ...], ...}
+//	}
+//
+// everything but the adjacency can be left out. the nodes list gives the
+// order of the nodes and where they are drawn (y grows upwards), the nodes
+// that are only in the adjacency come after it sorted by name. an edge
+// without a cost costs 1. in a graph that isn't directed every edge goes both
+// ways, so it only has to be in the list of one of its ends
+type jsonGraph struct {
+	Directed  bool                  `json:"directed"`
+	Start     string                `json:"start"`
+	Goal      string                `json:"goal"`
+	Nodes     []jsonNode            `json:"nodes"`
+	Adjacency map[string][]jsonEdge `json:"adjacency"`
+}
+
+type jsonNode struct {
+	ID string   `json:"id"`
+	X  *float64 `json:"x"`
+	Y  *float64 `json:"y"`
+}
+
+type jsonEdge struct {
+	To   string   `json:"to"`
+	Cost *float64 `json:"cost"`
+}
+
+// LoadJSONGraph reads a graph from a JSON adjacency list file
+func LoadJSONGraph(filename string) (*Network, error) {
+	return loadFile(filename, parseJSONGraph)
+}
+
+func parseJSONGraph(r io.Reader) (*Network, error) {
+	var g jsonGraph
+
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&g); err != nil {
+		return nil, err
+	}
+
+	n := &Network{}
+	coords := make(map[int]Coord)
+
+	for _, node := range g.Nodes {
+		if node.ID == "" {
+			return nil, fmt.Errorf("a node without an id")
+		}
+		if _, ok := n.Lookup(node.ID); ok {
+			return nil, fmt.Errorf("node %q is in the nodes twice", node.ID)
+		}
+
+		i := n.node(node.ID)
+
+		switch {
+		case node.X != nil && node.Y != nil:
+			coords[i] = Coord{X: *node.X, Y: *node.Y}
+		case node.X != nil || node.Y != nil:
+			return nil, fmt.Errorf("node %q needs both x and y", node.ID)
+		}
+	}
+
+	// the map has no order, sorted the file reads the same every time
+	from := slices.Sorted(maps.Keys(g.Adjacency))
+
+	for _, name := range from {
+		n.node(name)
+	}
+
+	for _, name := range from {
+		u := n.node(name)
+
+		for _, e := range g.Adjacency[name] {
+			if e.To == "" {
+				return nil, fmt.Errorf("an edge of %q without a \"to\"", name)
+			}
+
+			cost := 1.0
+			if e.Cost != nil {
+				cost = *e.Cost
+			}
+
+			if err := n.edge(u, n.node(e.To), cost, g.Directed); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := n.finish(coords, g.Start, g.Goal); err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
@@ -0,0 +1,86 @@
+package search
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseJSONGraph(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want networkWant
+	}{
+		{
+			name: "undirected with places and ends",
+			src: `{
+				"start": "home", "goal": "work",
+				"nodes": [{"id": "home", "x": 0, "y": 0}, {"id": "shop", "x": 1, "y": 0}, {"id": "work", "x": 2, "y": 1}],
+				"adjacency": {"home": [{"to": "shop", "cost": 2.5}], "shop": [{"to": "work"}]}
+			}`,
+			want: networkWant{
+				nodes: 3, edges: 2, start: "home", goal: "work",
+				costs: map[string]float64{"home>shop": 2.5, "shop>home": 2.5, "shop>work": 1, "work>shop": 1},
+			},
+		},
+		{
+			name: "directed",
+			src:  `{"directed": true, "adjacency": {"a": [{"to": "b", "cost": 3}], "b": [{"to": "c"}]}}`,
+			want: networkWant{
+				nodes: 3, edges: 2, start: "a", goal: "c",
+				costs:   map[string]float64{"a>b": 3, "b>c": 1},
+				missing: []string{"b>a", "c>b"},
+			},
+		},
+		{
+			name: "the nodes list comes first",
+			src:  `{"nodes": [{"id": "z"}, {"id": "y"}], "adjacency": {"a": [{"to": "z"}], "y": [{"to": "z", "cost": 0}]}}`,
+			want: networkWant{
+				nodes: 3, edges: 2, start: "z", goal: "a",
+				costs: map[string]float64{"a>z": 1, "z>y": 0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := parseJSONGraph(strings.NewReader(tt.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkNetwork(t, n, tt.want)
+		})
+	}
+}
+
+func TestParseJSONGraphErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		err  string
+	}{
+		{"not json", `graph { a -- b }`, "invalid character"},
+		{"unknown field", `{"weighted": true, "adjacency": {"a": [{"to": "b"}]}}`, `unknown field "weighted"`},
+		{"unknown edge field", `{"adjacency": {"a": [{"to": "b", "weight": 2}]}}`, `unknown field "weight"`},
+		{"wrong type", `{"adjacency": {"a": [{"to": "b", "cost": "2"}]}}`, "cannot unmarshal"},
+		{"node without an id", `{"nodes": [{"x": 1, "y": 2}]}`, "a node without an id"},
+		{"node twice", `{"nodes": [{"id": "a"}, {"id": "a"}]}`, `node "a" is in the nodes twice`},
+		{"only x", `{"nodes": [{"id": "a", "x": 1}, {"id": "b"}]}`, `node "a" needs both x and y`},
+		{"edge without a to", `{"adjacency": {"a": [{"cost": 1}]}}`, `an edge of "a" without a "to"`},
+		{"negative cost", `{"adjacency": {"a": [{"to": "b", "cost": -1}]}}`, "must be a number >= 0"},
+		{"unknown goal", `{"goal": "q", "adjacency": {"a": [{"to": "b"}]}}`, `the graph has no node "q"`},
+		{"one node", `{"nodes": [{"id": "a"}]}`, "the graph has 1 nodes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseJSONGraph(strings.NewReader(tt.src))
+			if err == nil {
+				t.Fatalf("no error, want %q", tt.err)
+			}
+			if !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("error %q, want %q in it", err, tt.err)
+			}
+		})
+	}
+}
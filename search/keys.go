@@ -0,0 +1,142 @@
+package search
+
+import (
+	"math/bits"
+	"strings"
+)
+
+// Keys is a set of keys, bit i is the key 'a'+i. in the maze file the keys
+// are the letters "a" - "z" and the doors they open are "C" - "Z", "A" and
+// "B" are the start and the goal so the keys "a" and "b" open nothing. "v"
+// is a one-way cell and not a key, so it has no door either
+type Keys uint32
+
+// keyOf is the key of the letter, a key or a door
+func keyOf(r rune) Keys {
+	switch {
+	case 'a' <= r && r <= 'z':
+		return 1 << (r - 'a')
+	case 'A' <= r && r <= 'Z':
+		return 1 << (r - 'A')
+	}
+	return 0
+}
+
+// Has tells if every key of k is in the set
+func (s Keys) Has(k Keys) bool {
+	return s&k == k
+}
+
+// Len is the number of keys in the set
+func (s Keys) Len() int {
+	return bits.OnesCount32(uint32(s))
+}
+
+// String lists the keys like "acf", the empty set is "-"
+func (s Keys) String() string {
+	if s == 0 {
+		return "-"
+	}
+
+	var b strings.Builder
+	for i := range 26 {
+		if s&(1<<i) != 0 {
+			b.WriteRune('a' + rune(i))
+		}
+	}
+	return b.String()
+}
+
+// KeyedState is the real state of a search in a maze with doors, the same
+// cell with other keys in the pocket is somewhere else in the search
+type KeyedState struct {
+	Point
+	Keys Keys
+}
+
+func (n *Node) keyed() KeyedState {
+	return KeyedState{Point: n.State, Keys: n.Keys}
+}
+
+// HasDoors tells if the maze has a door, only then the keys matter
+func (g *Maze) HasDoors() bool {
+	for z := range g.Levels() {
+		for _, row := range g.Floor(z) {
+			for _, w := range row {
+				if w.Door != 0 {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// KeysAt is the key set the solution held in the cell at p, the
+// last time when it went through more than once. ok is false when p is not
+// on the solution or the solution doesn't track keys
+func (g *Maze) KeysAt(p Point) (Keys, bool) {
+	s := g.Solution
+	for i := len(s.Keys) - 1; i >= 0; i-- {
+		if s.Cells[i] == p {
+			return s.Keys[i], true
+		}
+	}
+	return 0, false
+}
+
+// Pickup is a step of a solution where new keys were picked up
+type Pickup struct {
+	Step int
+	Keys Keys
+}
+
+// Pickups lists the steps of the solution that picked up a key, the steps
+// count from 1 like the solution's cells
+func (s Solution) Pickups() []Pickup {
+	var pickups []Pickup
+	var held Keys
+
+	for i, keys := range s.Keys {
+		if keys != held {
+			pickups = append(pickups, Pickup{Step: i + 1, Keys: keys &^ held})
+			held = keys
+		}
+	}
+	return pickups
+}
+
+// states is the number of different states a search can be in, every cell
+// with every set of the maze's keys
+func (g *Maze) states() int {
+	if g.Network != nil {
+		return g.Network.Len()
+	}
+
+	var all Keys
+	for z := range g.Levels() {
+		for _, row := range g.Floor(z) {
+			for _, w := range row {
+				all |= w.Key
+			}
+		}
+	}
+	return g.Width * g.Height * g.Levels() << all.Len()
+}
+
+// enter is what happens to the keys when the agent walks into the cell at p.
+// ok is false when there is a door and the agent has no key for it, otherwise
+// the key lying in the cell (if any) is picked up
+func (g *Maze) enter(keys Keys, p Point) (Keys, bool) {
+	if g.Network != nil {
+		return keys, true
+	}
+
+	w := g.at(p)
+
+	if w.Door != 0 && !keys.Has(w.Door) {
+		return keys, false
+	}
+
+	return keys | w.Key, true
+}
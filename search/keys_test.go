@@ -0,0 +1,72 @@
+package search
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestKeys(t *testing.T) {
+	if keyOf('c') != keyOf('C') {
+		t.Error("the door C doesn't take the key c")
+	}
+	if keyOf('#') != 0 || keyOf(' ') != 0 {
+		t.Error("a wall or an open cell is a key")
+	}
+
+	k := keyOf('a') | keyOf('c') | keyOf('f')
+	if k.String() != "acf" || k.Len() != 3 {
+		t.Errorf("keys %q with %d of them, want acf and 3", k, k.Len())
+	}
+	if !k.Has(keyOf('c')|keyOf('f')) || k.Has(keyOf('d')) {
+		t.Errorf("%s has the wrong keys", k)
+	}
+	if Keys(0).String() != "-" {
+		t.Errorf("no keys is %q, want -", Keys(0))
+	}
+}
+
+func TestKeysAndDoors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		// -1 when there is no path
+		cost    float64
+		pickups []Pickup
+	}{
+		{"key on the way", "AcC B\n", 4, []Pickup{{Step: 1, Keys: keyOf('c')}}},
+		{"no key", "A C B\n", -1, nil},
+		{"key behind its door", "A CcB\n", -1, nil},
+		{"detour for the key", "c####\nA C B\n", 6, []Pickup{{Step: 1, Keys: keyOf('c')}}},
+		{"one key behind another door", "A dDcC B\n", 7, []Pickup{{Step: 2, Keys: keyOf('d')}, {Step: 4, Keys: keyOf('c')}}},
+		{"a door that doesn't matter", "A   B\n#####\n  C  \n", 4, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"BFS", "Dijkstra", "AStar", "IDAStar"} {
+				m, err := NewMaze(strings.NewReader(tt.src))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := Solve(name, m); err != nil {
+					t.Fatal(err)
+				}
+
+				if got := pathCost(m); math.Abs(got-tt.cost) > 1e-9 {
+					t.Errorf("%s: cost %g, want %g", name, got, tt.cost)
+					continue
+				}
+				if tt.cost < 0 {
+					continue
+				}
+				checkPath(t, m)
+
+				if got := m.Solution.Pickups(); !slices.Equal(got, tt.pickups) {
+					t.Errorf("%s: picked up %v, want %v", name, got, tt.pickups)
+				}
+			}
+		})
+	}
+}
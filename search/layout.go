@@ -0,0 +1,89 @@
+package search
+
+import "math"
+
+// maxSpringNodes is the biggest network the spring layout places, a bigger
+// one goes round a circle. the layout compares every pair of nodes on every
+// round
+const maxSpringNodes = 400
+
+// layout places the nodes of a network that has no coordinates. they start
+// round a circle, then the edges pull the nodes they join together and all
+// the nodes push each other away (Fruchterman-Reingold) so the drawing
+// spreads out. it is the same for the same network every time
+func (n *Network) layout() {
+	count := n.Len()
+	n.Coords = make([]Coord, count)
+
+	for i := range n.Coords {
+		a := 2 * math.Pi * float64(i) / float64(count)
+		n.Coords[i] = Coord{X: math.Cos(a), Y: math.Sin(a)}
+	}
+
+	if count > maxSpringNodes {
+		return
+	}
+
+	// the distance the nodes would like to have from each other
+	k := math.Sqrt(4 / float64(count))
+	temp := 0.1
+	const rounds = 300
+
+	move := make([]Coord, count)
+
+	for range rounds {
+		clear(move)
+
+		for i := range count {
+			for j := i + 1; j < count; j++ {
+				dx, dy, d := n.apart(i, j)
+				f := k * k / d
+				move[i].X += dx / d * f
+				move[i].Y += dy / d * f
+				move[j].X -= dx / d * f
+				move[j].Y -= dy / d * f
+			}
+		}
+
+		for i, edges := range n.out {
+			for _, e := range edges {
+				j := e.End.X
+				if i == j {
+					continue
+				}
+
+				dx, dy, d := n.apart(i, j)
+				f := d * d / k
+				move[i].X -= dx / d * f
+				move[i].Y -= dy / d * f
+				move[j].X += dx / d * f
+				move[j].Y += dy / d * f
+			}
+		}
+
+		// every node moves at most temp, and that gets smaller every round
+		for i, m := range move {
+			d := math.Hypot(m.X, m.Y)
+			if d == 0 {
+				continue
+			}
+			step := min(d, temp)
+			n.Coords[i].X += m.X / d * step
+			n.Coords[i].Y += m.Y / d * step
+		}
+
+		temp *= 0.985
+	}
+}
+
+// apart is how far the node i is from j, never exactly 0 so the forces don't
+// blow up
+func (n *Network) apart(i, j int) (dx, dy, d float64) {
+	dx = n.Coords[i].X - n.Coords[j].X
+	dy = n.Coords[i].Y - n.Coords[j].Y
+	d = math.Hypot(dx, dy)
+	if d < 1e-6 {
+		dx, d = 1e-6, 1e-6
+	}
+	return dx, dy, d
+}
@@ -0,0 +1,172 @@
+package search
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// floorLine starts the next floor of a maze file, the rows before the first
+// one are floor 0 and every floor is one level up from the one before it.
+// "floor 3" makes the stairs up to the new floor cost 3, a floor line
+// without a number costs the DefaultStairCost
+const floorLine = "floor"
+
+// the stairs of a maze with floors. a "U" goes up to the cell in the same
+// row and column of the next floor, that cell has to be a "D" going back
+// down. in a maze of one floor the two letters are doors like the others
+const (
+	stairsUp   = 'U'
+	stairsDown = 'D'
+)
+
+// DefaultStairCost is the cost of taking the stairs between two floors when
+// the floor line sets no other
+const DefaultStairCost = 1.0
+
+// the actions of the moves that take the stairs
+const (
+	upstairs   = "upstairs"
+	downstairs = "downstairs"
+)
+
+// isFloorLine tells if the line of a maze file starts a floor and is not a row
+func isFloorLine(line string) bool {
+	return line == floorLine || strings.HasPrefix(line, floorLine+" ")
+}
+
+// parseFloorLine reads the cost of the stairs up to the floor the line starts
+func parseFloorLine(line string) (float64, error) {
+	field := strings.TrimSpace(strings.TrimPrefix(line, floorLine))
+	if field == "" {
+		return DefaultStairCost, nil
+	}
+
+	cost, err := strconv.ParseFloat(field, 64)
+	if err != nil || cost < 0 {
+		return 0, fmt.Errorf("bad floor line %q: the stair cost must be a number >= 0", line)
+	}
+	return cost, nil
+}
+
+// Levels is the number of floors of the maze, 1 when it has no floor lines
+func (g *Maze) Levels() int {
+	return max(1, len(g.Floors))
+}
+
+// Floor is the rows of the floor z, Walls for floor 0
+func (g *Maze) Floor(z int) [][]Wall {
+	if g.Floors == nil {
+		return g.Walls
+	}
+	return g.Floors[z]
+}
+
+// at is the cell at p on its floor
+func (g *Maze) at(p Point) *Wall {
+	return &g.Floor(p.Z)[p.X][p.Y]
+}
+
+// checkStairs makes sure every "U" has a "D" on the floor above it and every
+// "D" a "U" below it, so the stairs work both ways
+func (g *Maze) checkStairs() error {
+	for z := range g.Levels() {
+		for _, row := range g.Floor(z) {
+			for _, w := range row {
+				if w.Stairs == 0 {
+					continue
+				}
+
+				p := w.State
+				q := Point{X: p.X, Y: p.Y, Z: p.Z + w.Stairs}
+				if !g.contains(q) || g.at(q).Stairs != -w.Stairs {
+					want, where := "D", "above"
+					if w.Stairs < 0 {
+						want, where = "U", "below"
+					}
+					return fmt.Errorf("the stairs at %d,%d of floor %d have no %q %s them", p.X, p.Y, p.Z, want, where)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// stairs is the cell the stairs at p lead to, ok is false when there are none
+func (g *Maze) stairs(p Point) (Point, bool) {
+	if g.Floors == nil {
+		return Point{}, false
+	}
+
+	w := g.at(p)
+	if w.Stairs == 0 {
+		return Point{}, false
+	}
+	return Point{X: p.X, Y: p.Y, Z: p.Z + w.Stairs}, true
+}
+
+// stairCost is the cost of the stairs between the floor z and the one above it
+func (g *Maze) stairCost(z int) float64 {
+	if z < len(g.StairCosts) {
+		return g.StairCosts[z]
+	}
+	return DefaultStairCost
+}
+
+// climb is the cost of the stairs between two floors, every floor in between
+// is passed on the way
+func (g *Maze) climb(from, to int) float64 {
+	cost := 0.0
+	for z := min(from, to); z < max(from, to); z++ {
+		cost += g.stairCost(z)
+	}
+	return cost
+}
+
+// acrossFloors makes the heuristic count with the stairs. the stairs don't
+// move along the rows and columns, so a path to another floor walks at least
+// what the heuristic says and takes every stairs in between on top of it
+func (g *Maze) acrossFloors(h Heuristic) Heuristic {
+	return func(p, goal Point) float64 {
+		return h(p, goal) + g.climb(p.Z, goal.Z)
+	}
+}
+
+// stairsAction names the move between two floors
+func stairsAction(from, to Point) string {
+	if to.Z > from.Z {
+		return upstairs
+	}
+	return downstairs
+}
+
+// parseCell reads a cell written as row,col, or as row,col,floor in a maze
+// with floors
+func parseCell(s string) (Point, bool) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 && len(parts) != 3 {
+		return Point{}, false
+	}
+
+	var n [3]int
+	for i, part := range parts {
+		v, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return Point{}, false
+		}
+		n[i] = v
+	}
+
+	return Point{X: n[0], Y: n[1], Z: n[2]}, true
+}
+
+// LevelChanges counts the moves of the solution that took the stairs
+func (s Solution) LevelChanges() int {
+	n := 0
+	for _, a := range s.Actions {
+		if a == upstairs || a == downstairs {
+			n++
+		}
+	}
+	return n
+}
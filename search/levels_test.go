@@ -0,0 +1,131 @@
+package search
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// a cell is only explored on the floor the search explored it on, the cells
+// with the same row and column on the other floors are not
+func TestInExploredFloors(t *testing.T) {
+	m, err := LoadMaze("../maze-floors.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Levels() < 2 {
+		t.Fatalf("the maze has %d floors", m.Levels())
+	}
+	if err := Solve("AStar", m); err != nil {
+		t.Fatal(err)
+	}
+
+	marked := 0
+	for z := range m.Levels() {
+		for _, row := range m.Floor(z) {
+			for _, w := range row {
+				explored := slices.Contains(m.Explored, w.State)
+				if m.InExplored(w.State) != explored {
+					t.Errorf("InExplored(%v) = %t, want %t", w.State, !explored, explored)
+				}
+				if explored {
+					marked++
+				}
+			}
+		}
+	}
+
+	if marked == 0 {
+		t.Error("no cell was explored")
+	}
+}
+
+func TestFloorErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		err  string
+	}{
+		{"bad stair cost", "A U\nfloor up\nB D\n", `bad floor line "floor up"`},
+		{"negative stair cost", "A U\nfloor -1\nB D\n", "must be a number >= 0"},
+		{"empty floor", "A B\nfloor\n", "floor 1 has no rows"},
+		{"rows don't match", "A U\n   \nfloor\nB D\n", "floor 1 has 1 rows and floor 0 has 2"},
+		{"no way down", "A U\nfloor\nB  \n", `the stairs at 0,2 of floor 0 have no "D" above them`},
+		{"no way up", "A  \nfloor\nB D\n", `the stairs at 0,2 of floor 1 have no "U" below them`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewMaze(strings.NewReader(tt.src))
+			if err == nil {
+				t.Fatalf("no error, want %q", tt.err)
+			}
+			if !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("error %q, want %q in it", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestFloorSolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		diagonal bool
+		cost     float64
+	}{
+		{"default stairs", "A U\n###\nfloor\n  D\nB  \n", false, 2 + 1 + 3},
+		{"dear stairs", "A U\n###\nfloor 4\n  D\nB  \n", false, 2 + 4 + 3},
+		{"diagonal on the floor above", "A U\n###\nfloor 4\n  D\nB  \n", true, 2 + 4 + 1 + math.Sqrt2},
+		{"two floors up", "AU\nfloor 2\nUD\nfloor 3\nDB\n", false, 1 + 2 + 1 + 3 + 1},
+		{"free stairs", "A U\n###\nfloor 0\n  D\nB  \n", false, 2 + 0 + 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"Dijkstra", "AStar", "BiAStar", "IDAStar"} {
+				m, err := NewMaze(strings.NewReader(tt.src))
+				if err != nil {
+					t.Fatal(err)
+				}
+				m.Diagonal = tt.diagonal
+				if err := Solve(name, m); err != nil {
+					t.Fatal(err)
+				}
+
+				if got := pathCost(m); math.Abs(got-tt.cost) > 1e-9 {
+					t.Errorf("%s: cost %g, want %g", name, got, tt.cost)
+				}
+				checkPath(t, m)
+
+				if m.Solution.Cells[len(m.Solution.Cells)-1].Z != m.Levels()-1 {
+					t.Errorf("%s: the path ends on floor %d", name, m.Solution.Cells[len(m.Solution.Cells)-1].Z)
+				}
+			}
+		})
+	}
+}
+
+func TestClimb(t *testing.T) {
+	m := &Maze{StairCosts: []float64{2, 3}}
+
+	tests := []struct {
+		from, to int
+		want     float64
+	}{
+		{0, 0, 0},
+		{0, 1, 2},
+		{1, 2, 3},
+		{0, 2, 5},
+		{2, 0, 5},
+		// a floor without a floor line has the default
+		{2, 3, DefaultStairCost},
+	}
+
+	for _, tt := range tests {
+		if got := m.climb(tt.from, tt.to); got != tt.want {
+			t.Errorf("climb(%d, %d) = %g, want %g", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package search
+
+import "fmt"
+
+func init() {
+	Register(Algorithm{Name: "LPAStar", SearchType: LPASTAR, New: NewLifelongPlanningAstar, Can: Stepwise | Toggles})
+}
+
+// LifelongPlanningAstar (LPA*) finds the path from the start to the goal like
+// A* does, then flips the maze's Toggles one round at a time and repairs the
+// path after every round instead of searching again from scratch
+type LifelongPlanningAstar struct {
+	Game *Maze
+}
+
+func NewLifelongPlanningAstar(m *Maze) Searcher {
+	return &LifelongPlanningAstar{Game: m}
+}
+
+func (l *LifelongPlanningAstar) Solve() {
+
+	if l.Game.Debug {
+		fmt.Println("Starting to solve maze using Lifelong Planning AStar Search...")
+	}
+
+	m := l.Game
+	m.resetIncremental()
+
+	s := newIncremental(m, m.Start, m.Goal, true)
+
+	m.Label = "LPA* first plan"
+	s.plan()
+	l.publish(s)
+
+	toggles := rounds(m.Toggles)
+
+	for i, round := range toggles {
+		m.Changed = s.toggle(round)
+
+		m.Label = fmt.Sprintf("LPA* walls changed (%d/%d), replanning", i+1, len(toggles))
+		m.frame()
+
+		s.plan()
+		l.publish(s)
+	}
+
+	m.Changed = nil
+}
+
+// publish makes the current path the Solution and shows it in a frame
+func (l *LifelongPlanningAstar) publish(s *incremental) {
+	m := l.Game
+
+	cells, ok := s.path()
+	if !ok {
+		m.Solution = Solution{}
+		m.Label = "LPA* no way to the goal"
+		m.frame()
+		return
+	}
+
+	// the path goes from the goal back to the start
+	for i, j := 0, len(cells)-1; i < j; i, j = i+1, j-1 {
+		cells[i], cells[j] = cells[j], cells[i]
+	}
+
+	m.Solution = m.walk(cells)
+	m.Label = fmt.Sprintf("LPA* plan %d: cost %g, %d expansions", len(m.Replans), m.Solution.Cost, m.Replans[len(m.Replans)-1])
+	m.frame()
+}
@@ -0,0 +1,763 @@
+// Package search loads ASCII mazes and solves them with the classic
+// graph search algorithms (DFS, BFS, Dijkstra, GBFS and A*).
+package search
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	DFS = iota
+	BFS
+	GBFS
+	ASTAR
+	DIJKSTRA
+	DLS
+	IDDFS
+	BIBFS
+	BIASTAR
+	JPS
+	IDASTAR
+	WASTAR
+	ARASTAR
+	LPASTAR
+	DSTARLITE
+	CBS
+	STASTAR
+	YEN
+)
+
+type Node struct {
+	index  int
+	State  Point
+	Parent *Node
+	Action string
+	// from starting to the current node's cost, the sum of the move
+	// costs of every cell on the way from the start
+	Cost float64
+
+	// the heuristic's estimate of the cost from this node to the goal,
+	// only the informed searches (A*, GBFS) fill it in
+	EstimatedCostToGoal float64
+
+	// the key that the PriorityFrontier orders the nodes by
+	Priority float64
+
+	// number of moves from the start
+	Depth int
+
+	// the keys picked up on the way here
+	Keys Keys
+}
+
+// calculate the cost from current node to the starting point
+func (n *Node) ManhattanDistance(goal Point) int {
+
+	return abs(n.State.X-goal.X) + abs(n.State.Y-goal.Y)
+
+}
+
+type Solution struct {
+	Actions []string
+	Cells   []Point
+	Cost    float64
+	// the keys held after every step, only set in mazes with doors
+	Keys []Keys
+}
+
+// maze's point on x and y axis, and the floor it is on
+type Point struct {
+	X int
+	Y int
+	// the floor of a maze with floors, 0 is the first one of the file
+	Z int
+}
+
+// where the maze will be blocked
+// in maze.txt the "#" is the wall
+type Wall struct {
+	State  Point
+	IsWall bool
+	// the cost of moving into this cell, 1 for a normal open cell
+	Cost int
+	// the key lying in the cell and the key the door in the cell needs,
+	// 0 when there is none
+	Key  Keys
+	Door Keys
+	// the PortalTiles character of the portal in the cell, 0 when there
+	// is none
+	Portal rune
+	// the only way a move into or out of a one-way cell may go, the zero
+	// Point for the normal cells
+	OneWay Point
+	// 1 for stairs up to the next floor, -1 for stairs down and 0 when there
+	// are none
+	Stairs int
+}
+
+// Terrain is the legend of the weighted cells in the maze file, moving into
+// one of these cells costs the given amount instead of 1.
+// the digits "1" - "9" can also be used to set the cost directly
+var Terrain = map[rune]int{
+	'.': 2, // grass
+	'~': 5, // water
+}
+
+type Maze struct {
+	Height      int
+	Width       int
+	Start       Point
+	Goal        Point
+	Walls       [][]Wall
+	CurrentNode *Node
+	Solution    Solution
+	Explored    []Point
+	Steps       int
+	NumExplored int
+	// the cost from the start of every explored cell
+	Costs map[Point]float64
+	// put closed nodes back into the frontier when a cheaper way to them
+	// is found, only needed when the heuristic is not consistent
+	Reopen bool
+	// how many times a closed node was put back into the frontier
+	Reopened int
+	// the deepest a depth limited search may go, IDDFS sets it to the
+	// limit of its last iteration
+	DepthLimit int
+	// how many iterations an iterative search ran
+	Iterations int
+	// the f-cost bound of the last IDA* iteration
+	Threshold float64
+	// how many expansions of an IDA* pass the pass before it already did
+	Reexpanded int
+	// the cells the backward half of a bidirectional search explored
+	// (the half that starts at the goal), Explored has the forward half
+	BackwardExplored []Point
+	// where the two halves of a bidirectional search met
+	MeetingPoint *Point
+	// the jump points Jump Point Search expanded, Explored has every
+	// cell its jumps went over
+	JumpPoints []Point
+	// the w that weighted A* multiplies the heuristic with, ARA* starts
+	// with it. 0 means the algorithm's default
+	Weight float64
+	// the w of the ARA* pass that is running, or of its last one
+	passWeight float64
+	// every solution an anytime search found, the last one is Solution
+	Phases []Phase
+	// a line of text about the state of the search, drawn on the frames
+	Label string
+	// allow the 4 diagonal moves as well, each one costs √2 times the
+	// cost of the cell it goes into
+	Diagonal bool
+	// when a diagonal move may go past the corner of a wall
+	Corners CornerRule
+	// the cells are hexes with six cells around them instead of squares,
+	// the maze file started with the hex line
+	Hex bool
+	// the rows of every floor of a maze with more than one, Walls is floor
+	// 0. nil when the maze has one floor
+	Floors [][][]Wall
+	// the cost of the stairs between every floor and the one above it
+	StairCosts []float64
+	// the walls that LPA* and D* Lite flip while they run
+	Toggles []Toggle
+	// how many cells each plan of an incremental search expanded, the
+	// first plan and then one for every replan
+	Replans []int
+	// where the agent of D* Lite is, nil when there is none
+	Agent *Point
+	// the cells the agent walked through, the start included
+	Trail []Point
+	// the cells whose walls were flipped last
+	Changed []Point
+	// every portal cell and the cell it teleports to, nil when the maze
+	// has no portals
+	Portals map[Point]Point
+	// the cost of going through a portal
+	PortalCost float64
+	// every start of the maze, Start is the first one. a search from all of
+	// them sets Start to the one its solution comes from
+	Starts []Point
+	// the index in Starts of the closest start of every cell and the cost
+	// from it, only filled in when there is more than one start
+	Regions     map[Point]int
+	RegionCosts map[Point]float64
+	// every goal of the maze, Goal is the first one
+	Goals []Point
+	// what to do when the maze has more than one goal
+	GoalMode GoalMode
+	// the legs of the tour when the goals are visited all
+	Tour []Leg
+	// the agents of a multi-agent search and the plan of every one of
+	// them, a plan has a cell for every time step until the agent is at
+	// its goal. the numbered starts and goals of the file are the agents
+	// right away
+	Agents []Agent
+	Plans  []Solution
+	// where every agent is at the time step the animation shows, nil when
+	// there are no agents to show
+	AgentsAt []Point
+	// the obstacles that move around the maze on their routes, and the time
+	// step the animation frames show them at
+	Obstacles []Obstacle
+	Time      int
+	// the time step the agent leaves the start at, every leg of a tour that
+	// visits all goals starts when the leg before it ended
+	StartTime int
+	// how many conflicts the agents' own shortest paths had and how many
+	// nodes of the constraint tree CBS expanded to get rid of them
+	Conflicts       int
+	ConstraintNodes int
+	// how many paths Yen's algorithm looks for, DefaultK when 0, and the
+	// ones it found from the cheapest on. Solution is the first one
+	K     int
+	Paths []Solution
+	// the graph of a maze loaded from a graph file, its nodes are the
+	// states instead of the cells. nil for the mazes of a grid
+	Network *Network
+	// the heuristic for the informed searches, DefaultHeuristic when nil
+	Heuristic  Heuristic
+	Debug      bool
+	SearchType int
+	Animate    bool
+
+	// Frame is called after every explored node when Animate is set,
+	// the renderer hooks in here to write the animation frames
+	Frame func(m *Maze)
+}
+
+// LoadMaze reads a maze from a text file, from a Moving AI benchmark map when
+// the file ends in ".map", or from a graph file when one of the GraphLoaders
+// knows its extension
+func LoadMaze(filename string) (*Maze, error) {
+
+	if l, ok := graphLoader(filename); ok {
+		n, err := l.Load(filename)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filename, err)
+		}
+
+		return NewGraphMaze(n), nil
+	}
+
+	f, err := os.Open(filename)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	defer f.Close()
+
+	parse := NewMaze
+	if strings.EqualFold(filepath.Ext(filename), ".map") {
+		parse = NewMovingAIMap
+	}
+
+	m, err := parse(f)
+
+	if err != nil {
+
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	return m, nil
+}
+
+// NewMaze reads a maze where "#" is a wall, " " is an open cell,
+// "A" is a start and "B" is a goal, there can be more than one of both. the
+// Terrain characters and the digits "1" - "9" are open cells with a higher
+// move cost, "a" - "z" are keys and "C" - "Z" the doors they open. the
+// PortalTiles are portals, each one paired with the other cell of the same
+// character. "^", "v", "<" and ">" are one-way cells, see OneWayTiles. "v"
+// is always the one going down, so there is no key "v" and a door "V" is an
+// error. "A1" and "B1", "A2" and "B2" and so on are the starts and goals of
+// numbered agents, the number is part of the cell and not a cost, see
+// rowCells. the lines that start with "guard" are the routes of moving
+// obstacles, not rows of the maze. a maze whose first line is "hex" has
+// hexes for cells, see hexLine. the floor lines split the rows into floors
+// linked by the "U" and "D" stairs, see floorLine
+func NewMaze(r io.Reader) (*Maze, error) {
+
+	var fileContents []string
+	var guards []string
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if isGuardLine(line) {
+			guards = append(guards, line)
+			continue
+		}
+
+		fileContents = append(fileContents, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+
+		return nil, err
+	}
+
+	hex := len(fileContents) > 0 && fileContents[0] == hexLine
+	if hex {
+		fileContents = fileContents[1:]
+	}
+
+	foundStart, foundEnd := false, false
+
+	for _, line := range fileContents {
+
+		if strings.Contains(line, "A") {
+
+			foundStart = true
+		}
+
+		if strings.Contains(line, "B") {
+
+			foundEnd = true
+		}
+
+	}
+
+	if !foundStart {
+		return nil, fmt.Errorf("starting point ('A') not found")
+
+	}
+
+	if !foundEnd {
+
+		return nil, fmt.Errorf("ending point ('B') not found")
+	}
+
+	// the floor lines split the rows into floors, firstLine is where the
+	// rows of every floor start
+	floors := [][]string{nil}
+	firstLine := []int{0}
+	var stairCosts []float64
+
+	for n, line := range fileContents {
+		if !isFloorLine(line) {
+			floors[len(floors)-1] = append(floors[len(floors)-1], line)
+			continue
+		}
+
+		cost, err := parseFloorLine(line)
+		if err != nil {
+			return nil, err
+		}
+		stairCosts = append(stairCosts, cost)
+		floors = append(floors, nil)
+		firstLine = append(firstLine, n+1)
+	}
+
+	m := &Maze{Hex: hex}
+
+	m.Height = len(floors[0])
+
+	for z, floor := range floors {
+		if len(floor) == 0 {
+			return nil, fmt.Errorf("floor %d has no rows", z)
+		}
+		if len(floor) != m.Height {
+			return nil, fmt.Errorf("floor %d has %d rows and floor 0 has %d, every floor needs the same number", z, len(floor), m.Height)
+		}
+
+		for _, line := range floor {
+
+			cells, _ := rowCells(line)
+			m.Width = max(m.Width, len(cells))
+		}
+	}
+
+	// the stairs only link floors, in a maze of one floor "U" and "D" are
+	// doors
+	hasStairs := len(floors) > 1
+
+	// the cells of the numbered agents by their numbers
+	numberedStarts, numberedGoals := make(map[int]Point), make(map[int]Point)
+
+	for z, floor := range floors {
+
+		var rows [][]Wall
+
+		for i, row := range floor {
+
+			line := firstLine[z] + i + 1
+
+			var cols []Wall
+
+			cells, numbers := rowCells(row)
+
+			for j, col := range cells {
+				var wall Wall
+				wall.State = Point{X: i, Y: j, Z: z}
+				wall.Cost = 1
+
+				switch {
+
+				case col == 'A':
+					m.Starts = append(m.Starts, wall.State)
+					if n := numbers[j]; n > 0 {
+						if _, ok := numberedStarts[n]; ok {
+							return nil, fmt.Errorf("A%d at line %d: agent %d has another start", n, line, n)
+						}
+						numberedStarts[n] = wall.State
+					}
+
+				case col == 'B':
+					m.Goals = append(m.Goals, wall.State)
+					if n := numbers[j]; n > 0 {
+						if _, ok := numberedGoals[n]; ok {
+							return nil, fmt.Errorf("B%d at line %d: agent %d has another goal", n, line, n)
+						}
+						numberedGoals[n] = wall.State
+					}
+
+				case col == ' ':
+
+				case col == '#':
+					wall.IsWall = true
+
+				case '1' <= col && col <= '9':
+					wall.Cost = int(col - '0')
+
+				case Terrain[col] > 0:
+					wall.Cost = Terrain[col]
+
+				case OneWayTiles[col] != Point{}:
+					// the arrows point along the rows and columns of squares
+					if hex {
+						return nil, fmt.Errorf("one-way cell %q at line %d: a hex maze can't have one-way cells", col, line)
+					}
+					wall.OneWay = OneWayTiles[col]
+
+				case hasStairs && col == stairsUp:
+					wall.Stairs = 1
+
+				case hasStairs && col == stairsDown:
+					wall.Stairs = -1
+
+				case 'a' <= col && col <= 'z':
+					wall.Key = keyOf(col)
+
+				case col == reservedDoor:
+					return nil, fmt.Errorf("door %q at line %d: \"v\" is the one-way cell going down and not a key, so there is no door %q", col, line, col)
+
+				case 'C' <= col && col <= 'Z':
+					wall.Door = keyOf(col)
+
+				case strings.ContainsRune(PortalTiles, col):
+					wall.Portal = col
+
+				default:
+					return nil, fmt.Errorf("unknown character %q at line %d", col, line)
+				}
+
+				cols = append(cols, wall)
+
+			}
+
+			// short lines are closed with walls so every row has the same width
+			for j := len(cols); j < m.Width; j++ {
+				cols = append(cols, Wall{State: Point{X: i, Y: j, Z: z}, IsWall: true})
+			}
+
+			rows = append(rows, cols)
+
+		}
+
+		m.Floors = append(m.Floors, rows)
+	}
+
+	m.Walls = m.Floors[0]
+	if hasStairs {
+		m.StairCosts = stairCosts
+		if err := m.checkStairs(); err != nil {
+			return nil, err
+		}
+	} else {
+		m.Floors = nil
+	}
+
+	m.Start = m.Starts[0]
+	m.Goal = m.Goals[0]
+	m.PortalCost = DefaultPortalCost
+
+	if err := m.pairPortals(); err != nil {
+		return nil, err
+	}
+
+	for _, line := range guards {
+		o, err := m.parseObstacle(line)
+		if err != nil {
+			return nil, err
+		}
+		m.Obstacles = append(m.Obstacles, o)
+	}
+
+	if _, err := m.ObstaclePeriod(); err != nil {
+		return nil, err
+	}
+
+	if len(numberedStarts) > 0 || len(numberedGoals) > 0 {
+		if err := m.numberAgents(numberedStarts, numberedGoals); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+
+}
+
+func (g *Maze) PrintMaze() {
+	if g.Network != nil {
+		fmt.Println(strings.Join(g.Route(), " → "))
+		return
+	}
+
+	for z := range g.Levels() {
+		if g.Floors != nil {
+			fmt.Printf("floor %d\n", z)
+		}
+
+		for r, row := range g.Floor(z) {
+			// a hex is two characters wide and the odd rows are shifted by
+			// half of one
+			if g.Hex && r%2 == 1 {
+				fmt.Print(" ")
+			}
+
+			for _, col := range row {
+				if col.IsWall {
+					fmt.Print("█")
+				} else if g.IsStart(col.State) {
+					fmt.Print("A")
+				} else if g.IsGoal(col.State) {
+					fmt.Print("B")
+				} else if col.Stairs > 0 {
+					fmt.Print(string(stairsUp))
+				} else if col.Stairs < 0 {
+					fmt.Print(string(stairsDown))
+				} else if col.Key != 0 {
+					fmt.Print(col.Key)
+				} else if col.Door != 0 {
+					fmt.Print(strings.ToUpper(col.Door.String()))
+				} else if col.Portal != 0 {
+					fmt.Print(string(col.Portal))
+				} else if col.OneWay != (Point{}) {
+					fmt.Print(string(arrowOf(col.OneWay)))
+				} else if g.InSolution(col.State) {
+					fmt.Print("*")
+				} else if col.Cost > 1 {
+					fmt.Print(col.Cost)
+				} else {
+					fmt.Print(" ")
+				}
+
+				if g.Hex && col.IsWall {
+					fmt.Print("█")
+				} else if g.Hex {
+					fmt.Print(" ")
+				}
+			}
+			fmt.Println()
+		}
+	}
+}
+
+func (g *Maze) InSolution(x Point) bool {
+	for _, step := range g.Solution.Cells {
+		if step == x {
+			return true
+		}
+	}
+	return false
+}
+
+// Neighbors are the open cells next to the node, the diagonal ones too when
+// the maze allows Diagonal moves, and the partner of a portal. a door is only
+// open with its key, the neighbors pick up the key lying in their cell. on a
+// Network they are the nodes the edges of the node go to
+func (g *Maze) Neighbors(node *Node) []*Node {
+	var neighbors []*Node
+	for _, e := range g.Graph().Edges(node.State) {
+		keys, ok := g.enter(node.Keys, e.End)
+		if !ok {
+			continue
+		}
+
+		neighbors = append(neighbors, &Node{
+			State:  e.End,
+			Parent: node,
+			Action: e.Action,
+			Cost:   node.Cost + e.Cost,
+			Depth:  node.Depth + 1,
+			Keys:   keys,
+		})
+	}
+
+	shuffle(neighbors)
+
+	return neighbors
+}
+
+// Predecessors are the cells from which the node can be reached, the backward
+// half of a bidirectional search walks these. the Cost of each one is the cost
+// of getting from it to the goal through the node and its Action is the one
+// of the move from it to the node. with one-way cells these are not the same
+// as the Neighbors
+func (g *Maze) Predecessors(node *Node) []*Node {
+	var predecessors []*Node
+	for _, e := range g.Graph().InEdges(node.State) {
+		predecessors = append(predecessors, &Node{
+			State:  e.End,
+			Parent: node,
+			Action: e.Action,
+			Cost:   node.Cost + e.Cost,
+			Depth:  node.Depth + 1,
+		})
+	}
+
+	shuffle(predecessors)
+
+	return predecessors
+}
+
+// candidates are the cells around p that a move could go to or come from,
+// the ones outside the maze too. a hex has six of them, and stairs add the
+// cell on the other floor
+func (g *Maze) candidates(p Point) []Edge {
+	row := p.X
+	col := p.Y
+	z := p.Z
+
+	var candidates []Edge
+
+	if g.Hex {
+		candidates = hexCandidates(p)
+	} else {
+		// possible neighbors (that's why i named it candidates)
+		candidates = []Edge{
+			{End: Point{X: row - 1, Y: col, Z: z}, Action: "up"},
+			{End: Point{X: row + 1, Y: col, Z: z}, Action: "down"},
+			{End: Point{X: row, Y: col - 1, Z: z}, Action: "left"},
+			{End: Point{X: row, Y: col + 1, Z: z}, Action: "right"},
+		}
+
+		if g.Diagonal {
+			candidates = append(candidates,
+				Edge{End: Point{X: row - 1, Y: col - 1, Z: z}, Action: "up-left"},
+				Edge{End: Point{X: row - 1, Y: col + 1, Z: z}, Action: "up-right"},
+				Edge{End: Point{X: row + 1, Y: col - 1, Z: z}, Action: "down-left"},
+				Edge{End: Point{X: row + 1, Y: col + 1, Z: z}, Action: "down-right"},
+			)
+		}
+	}
+
+	if to, ok := g.partner(p); ok {
+		// a partner next to p that no move gets to is only teleported to
+		candidates = slices.DeleteFunc(candidates, func(e Edge) bool { return e.End == to })
+		candidates = append(candidates, Edge{End: to, Action: teleport})
+	}
+
+	if to, ok := g.stairs(p); ok {
+		candidates = append(candidates, Edge{End: to, Action: stairsAction(p, to)})
+	}
+
+	return candidates
+}
+
+// randomness of each node's neighbors each time
+func shuffle(nodes []*Node) {
+	for i := range nodes {
+		j := rand.Intn(i + 1)
+		nodes[i], nodes[j] = nodes[j], nodes[i]
+	}
+}
+
+// actionTo names the move that goes from one cell to the cell next to it,
+// a diagonal move is named like "up-left"
+func actionTo(from, to Point) string {
+	var vertical, horizontal string
+
+	switch {
+	case to.X < from.X:
+		vertical = "up"
+	case to.X > from.X:
+		vertical = "down"
+	}
+
+	switch {
+	case to.Y < from.Y:
+		horizontal = "left"
+	case to.Y > from.Y:
+		horizontal = "right"
+	}
+
+	if vertical != "" && horizontal != "" {
+		return vertical + "-" + horizontal
+	}
+
+	return vertical + horizontal
+}
+
+// MoveCost is the cost of moving into the cell at p
+func (g *Maze) MoveCost(p Point) float64 {
+	return float64(g.at(p).Cost)
+}
+
+// HasWeights tells if moving into some open cell costs more than 1
+func (g *Maze) HasWeights() bool {
+	for z := range g.Levels() {
+		for _, row := range g.Floor(z) {
+			for _, w := range row {
+				if !w.IsWall && w.Cost != 1 {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (g *Maze) InExplored(x Point) bool {
+	return inExplored(x, g.Explored)
+}
+
+// InBackwardExplored tells if the backward half of a bidirectional
+// search explored the cell
+func (g *Maze) InBackwardExplored(x Point) bool {
+	return inExplored(x, g.BackwardExplored)
+}
+
+// IsJumpPoint tells if Jump Point Search expanded the cell
+func (g *Maze) IsJumpPoint(x Point) bool {
+	return inExplored(x, g.JumpPoints)
+}
+
+// InTrail tells if the agent of D* Lite walked through the cell
+func (g *Maze) InTrail(x Point) bool {
+	return inExplored(x, g.Trail)
+}
+
+// IsChanged tells if the cell's wall was just flipped
+func (g *Maze) IsChanged(x Point) bool {
+	return inExplored(x, g.Changed)
+}
+
+// frame hands the current state of the search to the Frame hook
+func (g *Maze) frame() {
+	if g.Animate && g.Frame != nil {
+		g.Frame(g)
+	}
+}
@@ -0,0 +1,233 @@
+package search
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// the tiles of a Moving AI benchmark map. "." and "G" are ground and "S" is
+// swamp, the optimal lengths of the scenarios walk all of them at cost 1.
+// "@" and "O" are out of bounds, "T" are trees and "W" is water, none of
+// them are walked
+const (
+	movingAIOpen  = ".GS"
+	movingAIWalls = "@OTW"
+)
+
+// ScenarioTolerance is how far the cost of a path may be from the optimal
+// length of its scenario and still match it, the files round the lengths
+const ScenarioTolerance = 1e-4
+
+// NewMovingAIMap reads a grid map of the Moving AI pathfinding benchmarks, a
+// header with the type, height and width and then the rows after the "map"
+// line. the maps have no start and no goal, the first open cell is the start
+// and the last one the goal until a scenario (or -from and -to) picks others
+func NewMovingAIMap(r io.Reader) (*Maze, error) {
+	scanner := bufio.NewScanner(r)
+	line := 0
+
+	// the header
+	m := &Maze{}
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "map" {
+			break
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: want a header like \"height 32\" or the \"map\" line", line)
+		}
+
+		switch fields[0] {
+		case "type":
+			if fields[1] != "octile" {
+				return nil, fmt.Errorf("line %d: the map type is %q, only octile maps are known", line, fields[1])
+			}
+		case "height", "width":
+			n, err := strconv.Atoi(fields[1])
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("line %d: the %s must be a number > 0", line, fields[0])
+			}
+			if fields[0] == "height" {
+				m.Height = n
+			} else {
+				m.Width = n
+			}
+		default:
+			return nil, fmt.Errorf("line %d: unknown header %q", line, fields[0])
+		}
+	}
+
+	if m.Height == 0 || m.Width == 0 {
+		return nil, fmt.Errorf("the map has no height or no width before the \"map\" line")
+	}
+
+	// the rows
+	for scanner.Scan() {
+		line++
+		row := strings.TrimRight(scanner.Text(), "\r")
+		if row == "" {
+			continue
+		}
+		if len(m.Walls) == m.Height {
+			return nil, fmt.Errorf("line %d: the map has more than %d rows", line, m.Height)
+		}
+		if len(row) != m.Width {
+			return nil, fmt.Errorf("line %d: the row has %d tiles, the map is %d wide", line, len(row), m.Width)
+		}
+
+		i := len(m.Walls)
+		cols := make([]Wall, m.Width)
+		for j, tile := range row {
+			cols[j] = Wall{State: Point{X: i, Y: j}, Cost: 1}
+
+			switch {
+			case strings.ContainsRune(movingAIOpen, tile):
+			case strings.ContainsRune(movingAIWalls, tile):
+				cols[j].IsWall = true
+			default:
+				return nil, fmt.Errorf("line %d: unknown tile %q", line, tile)
+			}
+		}
+		m.Walls = append(m.Walls, cols)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(m.Walls) != m.Height {
+		return nil, fmt.Errorf("the map has %d rows, the header says %d", len(m.Walls), m.Height)
+	}
+
+	var open []Point
+	for _, row := range m.Walls {
+		for _, w := range row {
+			if !w.IsWall {
+				open = append(open, w.State)
+			}
+		}
+	}
+	if len(open) < 2 {
+		return nil, fmt.Errorf("the map has %d open tiles, it needs a start and a goal", len(open))
+	}
+
+	m.setEnds(open[0], open[len(open)-1])
+	m.PortalCost = DefaultPortalCost
+
+	return m, nil
+}
+
+// Scenario is one problem of a Moving AI scenario file, a start and a goal on
+// one of the maps and the length of the shortest path between them
+type Scenario struct {
+	Bucket int
+	// the map file as the scenario file names it
+	Map string
+	// the size of the map
+	Width, Height int
+	Start, Goal   Point
+	Optimal       float64
+}
+
+// LoadScenarios reads a Moving AI .scen file
+func LoadScenarios(filename string) ([]Scenario, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scenarios, err := ParseScenarios(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	return scenarios, nil
+}
+
+// ParseScenarios reads the problems of a scenario file, after the version
+// line every line is "bucket map width height startx starty goalx goaly
+// optimal". x is the column and y the row
+func ParseScenarios(r io.Reader) ([]Scenario, error) {
+	var scenarios []Scenario
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || (line == 1 && fields[0] == "version") {
+			continue
+		}
+		if len(fields) != 9 {
+			return nil, fmt.Errorf("line %d: want 9 fields (bucket map width height startx starty goalx goaly optimal), got %d", line, len(fields))
+		}
+
+		var n [7]int
+		for i, field := range []string{fields[0], fields[2], fields[3], fields[4], fields[5], fields[6], fields[7]} {
+			v, err := strconv.Atoi(field)
+			if err != nil || v < 0 {
+				return nil, fmt.Errorf("line %d: %q must be a number >= 0", line, field)
+			}
+			n[i] = v
+		}
+
+		optimal, err := strconv.ParseFloat(fields[8], 64)
+		if err != nil || optimal < 0 || math.IsInf(optimal, 0) {
+			return nil, fmt.Errorf("line %d: the optimal length %q must be a number >= 0", line, fields[8])
+		}
+
+		scenarios = append(scenarios, Scenario{
+			Bucket:  n[0],
+			Map:     fields[1],
+			Width:   n[1],
+			Height:  n[2],
+			Start:   Point{X: n[4], Y: n[3]},
+			Goal:    Point{X: n[6], Y: n[5]},
+			Optimal: optimal,
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(scenarios) == 0 {
+		return nil, fmt.Errorf("the file has no scenarios")
+	}
+
+	return scenarios, nil
+}
+
+// Matches tells if the cost of a path is the optimal length of the scenario
+func (s Scenario) Matches(cost float64) bool {
+	return math.Abs(cost-s.Optimal) <= ScenarioTolerance
+}
+
+// UseScenario makes the start and the goal of the scenario the ones of the
+// maze, the maze has to be the map of the scenario. a scenario may start on
+// its goal, the path is empty and its optimal length 0
+func (g *Maze) UseScenario(s Scenario) error {
+	if g.Width != s.Width || g.Height != s.Height {
+		return fmt.Errorf("the scenario is for a %d×%d map, the maze is %d×%d", s.Width, s.Height, g.Width, g.Height)
+	}
+
+	for _, p := range []Point{s.Start, s.Goal} {
+		if !g.contains(p) || g.Walls[p.X][p.Y].IsWall {
+			return fmt.Errorf("the cell %d,%d of the scenario is outside the map or not open", p.X, p.Y)
+		}
+	}
+
+	g.setEnds(s.Start, s.Goal)
+	return nil
+}
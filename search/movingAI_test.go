@@ -0,0 +1,253 @@
+package search
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewMovingAIMap(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         string
+		height      int
+		width       int
+		walls       []Point
+		start, goal Point
+	}{
+		{
+			name:   "every kind of tile",
+			src:    "type octile\nheight 2\nwidth 4\nmap\n.GS@\nOTW.\n",
+			height: 2, width: 4,
+			walls: []Point{{X: 0, Y: 3}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: 2}},
+			start: Point{X: 0, Y: 0}, goal: Point{X: 1, Y: 3},
+		},
+		{
+			name:   "header in another order with windows line ends",
+			src:    "width 3\r\nheight 2\r\n\r\nmap\r\n@..\r\n..@\r\n",
+			height: 2, width: 3,
+			walls: []Point{{X: 0, Y: 0}, {X: 1, Y: 2}},
+			start: Point{X: 0, Y: 1}, goal: Point{X: 1, Y: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMovingAIMap(strings.NewReader(tt.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if m.Height != tt.height || m.Width != tt.width {
+				t.Errorf("the map is %d×%d, want %d×%d", m.Width, m.Height, tt.width, tt.height)
+			}
+			if m.Start != tt.start || m.Goal != tt.goal {
+				t.Errorf("start %v goal %v, want %v and %v", m.Start, m.Goal, tt.start, tt.goal)
+			}
+
+			walls := 0
+			for _, row := range m.Walls {
+				for _, w := range row {
+					if w.IsWall {
+						walls++
+					}
+					if w.Cost != 1 {
+						t.Errorf("the tile %v costs %d, want 1", w.State, w.Cost)
+					}
+				}
+			}
+			if walls != len(tt.walls) {
+				t.Errorf("%d walls, want %d", walls, len(tt.walls))
+			}
+			for _, p := range tt.walls {
+				if !m.Walls[p.X][p.Y].IsWall {
+					t.Errorf("the tile %v should be a wall", p)
+				}
+			}
+		})
+	}
+}
+
+func TestNewMovingAIMapErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		err  string
+	}{
+		{"other map type", "type tile\nheight 1\nwidth 2\nmap\n..\n", `line 1: the map type is "tile"`},
+		{"unknown header", "type octile\ndepth 3\n", `line 2: unknown header "depth"`},
+		{"header without a value", "height\n", `want a header like "height 32"`},
+		{"bad height", "height -1\n", "the height must be a number > 0"},
+		{"no width", "type octile\nheight 1\nmap\n..\n", "no height or no width"},
+		{"no map line", "height 1\nwidth 2\n", "the map has 0 rows, the header says 1"},
+		{"short row", "height 2\nwidth 3\nmap\n...\n..\n", "line 5: the row has 2 tiles, the map is 3 wide"},
+		{"unknown tile", "height 1\nwidth 3\nmap\n.x.\n", `line 4: unknown tile 'x'`},
+		{"too many rows", "height 1\nwidth 2\nmap\n..\n..\n", "line 5: the map has more than 1 rows"},
+		{"too few rows", "height 3\nwidth 2\nmap\n..\n..\n", "the map has 2 rows, the header says 3"},
+		{"one open tile", "height 1\nwidth 3\nmap\n@.@\n", "the map has 1 open tiles"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewMovingAIMap(strings.NewReader(tt.src))
+			if err == nil {
+				t.Fatalf("no error, want %q", tt.err)
+			}
+			if !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("error %q, want %q in it", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestParseScenarios(t *testing.T) {
+	src := "version 1\n" +
+		"0\trooms.map\t28\t20\t5\t5\t4\t5\t1.00000000\n" +
+		"\n" +
+		"3\trooms.map\t28\t20\t17\t9\t19\t7\t3.41421356\n"
+
+	scenarios, err := ParseScenarios(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Scenario{
+		{Bucket: 0, Map: "rooms.map", Width: 28, Height: 20, Start: Point{X: 5, Y: 5}, Goal: Point{X: 5, Y: 4}, Optimal: 1},
+		{Bucket: 3, Map: "rooms.map", Width: 28, Height: 20, Start: Point{X: 9, Y: 17}, Goal: Point{X: 7, Y: 19}, Optimal: 3.41421356},
+	}
+	if len(scenarios) != len(want) {
+		t.Fatalf("%d scenarios, want %d", len(scenarios), len(want))
+	}
+	for i := range want {
+		if scenarios[i] != want[i] {
+			t.Errorf("scenario %d is %+v, want %+v", i, scenarios[i], want[i])
+		}
+	}
+
+	if !want[1].Matches(2 + 1.4142135623) {
+		t.Error("2+√2 should match 3.41421356")
+	}
+	if want[1].Matches(3.5) {
+		t.Error("3.5 should not match 3.41421356")
+	}
+}
+
+func TestParseScenariosErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		err  string
+	}{
+		{"too few fields", "version 1\n0 rooms.map 28 20 5 5 4 5\n", "line 2: want 9 fields"},
+		{"too many fields", "0 rooms.map 28 20 5 5 4 5 1 extra\n", "line 1: want 9 fields"},
+		{"version after the first line", "0 rooms.map 28 20 5 5 4 5 1\nversion 1\n", "line 2: want 9 fields"},
+		{"negative start", "0 rooms.map 28 20 -5 5 4 5 1\n", `"-5" must be a number >= 0`},
+		{"bad bucket", "b rooms.map 28 20 5 5 4 5 1\n", `"b" must be a number >= 0`},
+		{"bad optimal length", "0 rooms.map 28 20 5 5 4 5 far\n", `the optimal length "far"`},
+		{"infinite optimal length", "0 rooms.map 28 20 5 5 4 5 +Inf\n", `the optimal length "+Inf"`},
+		{"no scenarios", "version 1\n", "the file has no scenarios"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseScenarios(strings.NewReader(tt.src))
+			if err == nil {
+				t.Fatalf("no error, want %q", tt.err)
+			}
+			if !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("error %q, want %q in it", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestUseScenario(t *testing.T) {
+	src := "type octile\nheight 2\nwidth 3\nmap\n..@\n...\n"
+
+	tests := []struct {
+		name     string
+		scenario Scenario
+		err      string
+	}{
+		{"fits", Scenario{Width: 3, Height: 2, Start: Point{X: 1, Y: 2}, Goal: Point{X: 0, Y: 0}}, ""},
+		{"other size", Scenario{Width: 2, Height: 3, Start: Point{X: 1, Y: 1}, Goal: Point{X: 0, Y: 0}}, "for a 2×3 map, the maze is 3×2"},
+		{"on a wall", Scenario{Width: 3, Height: 2, Start: Point{X: 0, Y: 2}, Goal: Point{X: 0, Y: 0}}, "the cell 0,2 of the scenario"},
+		{"outside", Scenario{Width: 3, Height: 2, Start: Point{X: 2, Y: 0}, Goal: Point{X: 0, Y: 0}}, "the cell 2,0 of the scenario"},
+		{"starts on its goal", Scenario{Width: 3, Height: 2, Start: Point{X: 1, Y: 1}, Goal: Point{X: 1, Y: 1}}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMovingAIMap(strings.NewReader(src))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = m.UseScenario(tt.scenario)
+			if tt.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if m.Start != tt.scenario.Start || m.Goal != tt.scenario.Goal {
+					t.Errorf("start %v goal %v, want the ones of the scenario", m.Start, m.Goal)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("no error, want %q", tt.err)
+			}
+			if !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("error %q, want %q in it", err, tt.err)
+			}
+		})
+	}
+}
+
+// a scenario that starts on its goal is solved by the empty path of length 0
+func TestScenarioOnItsGoal(t *testing.T) {
+	m, err := NewMovingAIMap(strings.NewReader("type octile\nheight 2\nwidth 2\nmap\n..\n..\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := Scenario{Width: 2, Height: 2, Start: Point{X: 1, Y: 0}, Goal: Point{X: 1, Y: 0}}
+	if err := m.UseScenario(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := Solve("AStar", m); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(m.Solution.Cells) != 0 || !s.Matches(m.Solution.Cost) {
+		t.Errorf("the path is %v with cost %g, want the empty one", m.Solution.Cells, m.Solution.Cost)
+	}
+}
+
+// the optimal lengths of the bundled scenario file are the ones A* finds on
+// the bundled map
+func TestMovingAIRooms(t *testing.T) {
+	scenarios, err := LoadScenarios("../movingai-rooms.scen")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range scenarios {
+		m, err := LoadMaze("../movingai-rooms.map")
+		if err != nil {
+			t.Fatal(err)
+		}
+		m.Diagonal = true
+		m.Corners = NoCornerCutting
+
+		if err := m.UseScenario(s); err != nil {
+			t.Fatal(err)
+		}
+		if err := Solve("AStar", m); err != nil {
+			t.Fatal(err)
+		}
+
+		if !s.Matches(m.Solution.Cost) {
+			t.Errorf("%v to %v costs %g, the scenario says %g", s.Start, s.Goal, m.Solution.Cost, s.Optimal)
+		}
+	}
+}
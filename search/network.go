@@ -0,0 +1,273 @@
+package search
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Network is a graph of named nodes and weighted edges, the road and network
+// graphs of the DIMACS, DOT and JSON files. the state of the node i is
+// Point{X: i}, so the searches walk it like the cells of a maze
+type Network struct {
+	// the name of every node, in the order of the file
+	Names []string
+	// where every node is drawn, y grows upwards like on a map. from the
+	// file when it has a place for every node, from the layout otherwise
+	Coords []Coord
+	// the node a search starts at and the one it looks for when nothing
+	// else is picked
+	Start, Goal int
+
+	out, in [][]Edge
+	index   map[string]int
+	edges   int
+}
+
+// Coord is where a node of a network is drawn
+type Coord struct {
+	X, Y float64
+}
+
+// Network is a Graph
+var _ Graph = (*Network)(nil)
+
+func (n *Network) Edges(p Point) []Edge {
+	return n.out[p.X]
+}
+
+func (n *Network) InEdges(p Point) []Edge {
+	return n.in[p.X]
+}
+
+// Len is the number of nodes
+func (n *Network) Len() int {
+	return len(n.Names)
+}
+
+// NumEdges is the number of edges, an undirected one counts once
+func (n *Network) NumEdges() int {
+	return n.edges
+}
+
+// Lookup finds a node by its name
+func (n *Network) Lookup(name string) (Point, bool) {
+	i, ok := n.index[name]
+	return Point{X: i}, ok
+}
+
+// Name is the name of the node at p
+func (n *Network) Name(p Point) string {
+	return n.Names[p.X]
+}
+
+// node is the number of the node with the name, it is added when the
+// network doesn't have it yet
+func (n *Network) node(name string) int {
+	if n.index == nil {
+		n.index = make(map[string]int)
+	}
+
+	if i, ok := n.index[name]; ok {
+		return i
+	}
+
+	i := len(n.Names)
+	n.index[name] = i
+	n.Names = append(n.Names, name)
+	n.out = append(n.out, nil)
+	n.in = append(n.in, nil)
+	return i
+}
+
+// edge adds the edge from the node u to v, and the one back when it isn't
+// directed. the action of a move is the name of the node it goes to
+func (n *Network) edge(u, v int, cost float64, directed bool) error {
+	if math.IsNaN(cost) || math.IsInf(cost, 0) || cost < 0 {
+		return fmt.Errorf("edge %s to %s: the cost %g must be a number >= 0", n.Names[u], n.Names[v], cost)
+	}
+
+	n.out[u] = append(n.out[u], Edge{End: Point{X: v}, Action: n.Names[v], Cost: cost})
+	n.in[v] = append(n.in[v], Edge{End: Point{X: u}, Action: n.Names[v], Cost: cost})
+
+	if !directed && u != v {
+		n.out[v] = append(n.out[v], Edge{End: Point{X: u}, Action: n.Names[u], Cost: cost})
+		n.in[u] = append(n.in[u], Edge{End: Point{X: v}, Action: n.Names[u], Cost: cost})
+	}
+
+	n.edges++
+	return nil
+}
+
+// finish checks the network once the file is read, picks the start and the
+// goal and places the nodes. coords are the places the file had, start and
+// goal the names of the nodes it named (the first and the last node when
+// they are empty)
+func (n *Network) finish(coords map[int]Coord, start, goal string) error {
+	if n.Len() < 2 {
+		return fmt.Errorf("the graph has %d nodes, it needs a start and a goal", n.Len())
+	}
+
+	n.Start, n.Goal = 0, n.Len()-1
+	for _, e := range []struct {
+		name string
+		to   *int
+	}{{start, &n.Start}, {goal, &n.Goal}} {
+		if e.name == "" {
+			continue
+		}
+
+		i, ok := n.index[e.name]
+		if !ok {
+			return fmt.Errorf("the graph has no node %q", e.name)
+		}
+		*e.to = i
+	}
+
+	if n.Start == n.Goal {
+		return fmt.Errorf("the start and the goal are both node %q", n.Names[n.Start])
+	}
+
+	if len(coords) == n.Len() {
+		n.Coords = make([]Coord, n.Len())
+		for i, c := range coords {
+			n.Coords[i] = c
+		}
+	} else {
+		n.layout()
+	}
+
+	return nil
+}
+
+// GraphLoader reads one graph file format, the files that end in one of its
+// extensions are loaded with it
+type GraphLoader struct {
+	Name string
+	Exts []string
+	Load func(filename string) (*Network, error)
+}
+
+var loaders []GraphLoader
+
+// RegisterLoader adds a graph file format, every format file calls it from
+// its init function
+func RegisterLoader(l GraphLoader) {
+	loaders = append(loaders, l)
+}
+
+// GraphLoaders returns every registered graph file format
+func GraphLoaders() []GraphLoader {
+	return loaders
+}
+
+// graphLoader finds the format of the file by its extension, the maze text
+// files have none
+func graphLoader(filename string) (GraphLoader, bool) {
+	ext := strings.ToLower(filepath.Ext(filename))
+	for _, l := range loaders {
+		for _, e := range l.Exts {
+			if e == ext {
+				return l, true
+			}
+		}
+	}
+	return GraphLoader{}, false
+}
+
+// loadFile opens the file for one of the loaders and reads it with parse
+func loadFile(filename string, parse func(r io.Reader) (*Network, error)) (*Network, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parse(f)
+}
+
+// NewGraphMaze makes a maze out of the network, the searches walk its edges
+// instead of the cells of a grid. it has no walls and no size
+func NewGraphMaze(n *Network) *Maze {
+	m := &Maze{Network: n, PortalCost: DefaultPortalCost}
+	m.setEnds(Point{X: n.Start}, Point{X: n.Goal})
+	return m
+}
+
+// PickNodes makes the start and the goal of the maze the nodes with the
+// names in a graph file, or the cells at row,col on a grid. an empty one keeps
+// the start or the goal there is, when both are empty the maze keeps all of
+// its starts and goals
+func (g *Maze) PickNodes(start, goal string) error {
+	if start == "" && goal == "" {
+		return nil
+	}
+
+	s, e := g.Start, g.Goal
+	var err error
+
+	if start != "" {
+		if s, err = g.pick(start); err != nil {
+			return err
+		}
+	}
+
+	if goal != "" {
+		if e, err = g.pick(goal); err != nil {
+			return err
+		}
+	}
+
+	if s == e {
+		if g.Network != nil {
+			return fmt.Errorf("the start and the goal are both node %q", g.Network.Name(s))
+		}
+		return fmt.Errorf("the start and the goal are both the cell %d,%d", s.X, s.Y)
+	}
+
+	g.setEnds(s, e)
+	return nil
+}
+
+// pick finds the node with the name, or the open cell at row,col on a grid
+func (g *Maze) pick(name string) (Point, error) {
+	if g.Network != nil {
+		p, ok := g.Network.Lookup(name)
+		if !ok {
+			return p, fmt.Errorf("the graph has no node %q", name)
+		}
+		return p, nil
+	}
+
+	p, ok := parseCell(name)
+	if !ok {
+		return Point{}, fmt.Errorf("bad cell %q: want row,col (or row,col,floor)", name)
+	}
+
+	if !g.contains(p) || g.at(p).IsWall {
+		return p, fmt.Errorf("bad cell %q: it is outside the maze or a wall", name)
+	}
+	return p, nil
+}
+
+func (g *Maze) setEnds(start, goal Point) {
+	g.Start, g.Goal = start, goal
+	g.Starts = []Point{start}
+	g.Goals = []Point{goal}
+}
+
+// Route is the names of the nodes the solution goes through, the start first
+func (g *Maze) Route() []string {
+	if g.Network == nil || len(g.Solution.Cells) == 0 {
+		return nil
+	}
+
+	route := []string{g.Network.Name(g.Start)}
+	for _, p := range g.Solution.Cells {
+		route = append(route, g.Network.Name(p))
+	}
+	return route
+}
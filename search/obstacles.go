@@ -0,0 +1,115 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxObstaclePeriod is the longest the obstacles may take until they are all
+// back where they started, a space-time search has a state for every cell at
+// every time step of it
+const MaxObstaclePeriod = 1000
+
+// Obstacle moves along its route one cell every time step, like a guard on
+// patrol. after the last cell of the route it starts over from the first
+// one, a guard that walks back and forth has the way back in its route too
+type Obstacle struct {
+	Route []Point
+}
+
+// At is the cell the obstacle is in at the time step
+func (o Obstacle) At(t int) Point {
+	return o.Route[t%len(o.Route)]
+}
+
+// guardPrefix starts the lines after the grid of a maze file that give the
+// route of an obstacle, like "guard 3,4 3,5 3,6 3,5"
+const guardPrefix = "guard"
+
+// isGuardLine tells if the line of a maze file is a route and not a row
+func isGuardLine(line string) bool {
+	return line == guardPrefix || strings.HasPrefix(line, guardPrefix+" ")
+}
+
+// parseObstacle reads the route of a guard line. every cell is row,col and
+// every step of the route (the one from the last cell to the first too) goes
+// to a cell next to it, or stays in the same one
+func (g *Maze) parseObstacle(line string) (Obstacle, error) {
+	var o Obstacle
+
+	fields := strings.Fields(strings.TrimPrefix(line, guardPrefix))
+	if len(fields) == 0 {
+		return o, fmt.Errorf("bad guard %q: the route has no cells", line)
+	}
+
+	for _, field := range fields {
+		p, ok := parseCell(field)
+		if !ok {
+			return o, fmt.Errorf("bad guard cell %q: want row,col (or row,col,floor) in numbers", field)
+		}
+
+		if !g.contains(p) || g.at(p).IsWall {
+			return o, fmt.Errorf("bad guard cell %q: it is outside the maze or a wall", field)
+		}
+
+		o.Route = append(o.Route, p)
+	}
+
+	for i, p := range o.Route {
+		q := o.Route[(i+1)%len(o.Route)]
+		if !g.nextTo(p, q) {
+			return o, fmt.Errorf("bad guard route %q: %d,%d to %d,%d is more than one step", line, p.X, p.Y, q.X, q.Y)
+		}
+	}
+
+	return o, nil
+}
+
+// ObstaclePeriod is how many time steps it takes until every obstacle is
+// back at the start of its route at the same time, 0 when there are none
+func (g *Maze) ObstaclePeriod() (int, error) {
+	if len(g.Obstacles) == 0 {
+		return 0, nil
+	}
+
+	period := 1
+	for _, o := range g.Obstacles {
+		period = period / gcd(period, len(o.Route)) * len(o.Route)
+		if period > MaxObstaclePeriod {
+			return 0, fmt.Errorf("the guards are only all back at their start after more than %d steps, make their routes fit better together", MaxObstaclePeriod)
+		}
+	}
+
+	return period, nil
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// obstacleBlocked tells if a move from a cell to another (the same cell for a
+// wait) that ends at time t runs into an obstacle, it either is in the cell
+// then or the two swap their cells
+func (g *Maze) obstacleBlocked(from, to Point, t int) bool {
+	for _, o := range g.Obstacles {
+		if o.At(t) == to {
+			return true
+		}
+		if t > 0 && o.At(t-1) == to && o.At(t) == from {
+			return true
+		}
+	}
+	return false
+}
+
+// ObstaclesAt are the cells of the obstacles at the time step
+func (g *Maze) ObstaclesAt(t int) []Point {
+	var cells []Point
+	for _, o := range g.Obstacles {
+		cells = append(cells, o.At(t))
+	}
+	return cells
+}
@@ -0,0 +1,108 @@
+package search
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestObstacleErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		err  string
+	}{
+		{"no cells", "A  B\nguard\n", "the route has no cells"},
+		{"not a cell", "A  B\nguard 0,1 x\n", `bad guard cell "x"`},
+		{"on a wall", "A# B\nguard 0,1\n", `bad guard cell "0,1": it is outside the maze or a wall`},
+		{"outside", "A  B\nguard 0,4\n", "outside the maze"},
+		{"too far", "A   B\nguard 0,1 0,3\n", "0,1 to 0,3 is more than one step"},
+		{"back to the first cell too far", "A   B\nguard 0,1 0,2 0,3\n", "0,3 to 0,1 is more than one step"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewMaze(strings.NewReader(tt.src))
+			if err == nil {
+				t.Fatalf("no error, want %q", tt.err)
+			}
+			if !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("error %q, want %q in it", err, tt.err)
+			}
+		})
+	}
+}
+
+// checkGuards checks that the plan of the maze gets to the goal one step or
+// wait at a time and never meets a guard, in a cell or by swapping cells
+func checkGuards(t *testing.T, m *Maze) {
+	t.Helper()
+
+	plan := m.Solution
+	if len(plan.Cells) == 0 || plan.Cells[len(plan.Cells)-1] != m.Goal {
+		t.Fatal("the plan doesn't get to the goal")
+	}
+
+	for step := 1; step <= len(plan.Cells); step++ {
+		from, to := position(plan, m.Start, step-1), position(plan, m.Start, step)
+		if m.obstacleBlocked(from, to, step) {
+			t.Errorf("the move %v to %v at step %d runs into a guard", from, to, step)
+		}
+		if from != to && !m.nextTo(from, to) {
+			t.Errorf("the move %v to %v at step %d is more than one step", from, to, step)
+		}
+	}
+}
+
+// space-time A* waits for a guard to get out of the way when that is the
+// cheapest
+func TestSpaceTimeAvoidsGuards(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		// -1 when there is no path
+		cost  float64
+		waits int
+	}{
+		{"guard in the corridor", "######\n#A  B#\n## ###\n######\nguard 1,2 1,2 1,2 2,2\n", 5, 2},
+		{"guard back and forth in the way", "#####\n#A B#\n#####\nguard 1,3 1,2\n", -1, 0},
+		{"no guards", "A  B\n", 3, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMaze(strings.NewReader(tt.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := Solve("STAStar", m); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := pathCost(m); math.Abs(got-tt.cost) > 1e-9 {
+				t.Fatalf("cost %g, want %g", got, tt.cost)
+			}
+			if tt.cost < 0 {
+				return
+			}
+			if got := m.Solution.Waits(); got != tt.waits {
+				t.Errorf("%d waits, want %d", got, tt.waits)
+			}
+			checkGuards(t, m)
+		})
+	}
+}
+
+func TestSpaceTimeBundled(t *testing.T) {
+	m, err := LoadMaze("../maze-guards.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Obstacles) == 0 {
+		t.Fatal("the maze has no guards")
+	}
+	if err := Solve("STAStar", m); err != nil {
+		t.Fatal(err)
+	}
+	checkGuards(t, m)
+}
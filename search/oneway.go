@@ -0,0 +1,57 @@
+package search
+
+// OneWayTiles are the one-way cells of the maze file and the way they go.
+// a move into one of them and a move out of it both have to go that way, so
+// a row of ">" is an aisle that can only be walked from left to right.
+// "v" being the way down, there is no key "v" and no door "V", see
+// reservedDoor
+var OneWayTiles = map[rune]Point{
+	'^': {X: -1, Y: 0},
+	'v': {X: 1, Y: 0},
+	'<': {X: 0, Y: -1},
+	'>': {X: 0, Y: 1},
+}
+
+// reservedDoor is the door of the key that is a one-way cell, a maze with it
+// could never be walked through it
+const reservedDoor = 'V'
+
+// arrowOf is the maze file character of the one-way direction d
+func arrowOf(d Point) rune {
+	for r, p := range OneWayTiles {
+		if p == d {
+			return r
+		}
+	}
+	return 0
+}
+
+// oneWay tells if the move between the two cells next to each other is
+// allowed by the one-way cells it leaves and enters
+func (g *Maze) oneWay(from, to Point) bool {
+	d := Point{X: to.X - from.X, Y: to.Y - from.Y}
+
+	if w := g.at(from).OneWay; w != (Point{}) && w != d {
+		return false
+	}
+
+	if w := g.at(to).OneWay; w != (Point{}) && w != d {
+		return false
+	}
+
+	return true
+}
+
+// HasOneWay tells if the maze has a one-way cell
+func (g *Maze) HasOneWay() bool {
+	for z := range g.Levels() {
+		for _, row := range g.Floor(z) {
+			for _, w := range row {
+				if w.OneWay != (Point{}) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package search
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOneWayTiles(t *testing.T) {
+	m, err := NewMaze(strings.NewReader("A^v<>B\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Point{{}, {X: -1}, {X: 1}, {Y: -1}, {Y: 1}, {}}
+	for j, d := range want {
+		w := m.Walls[0][j]
+		if w.OneWay != d {
+			t.Errorf("cell %d goes %v, want %v", j, w.OneWay, d)
+		}
+		if w.Key != 0 || w.Door != 0 {
+			t.Errorf("cell %d is a key or a door", j)
+		}
+	}
+}
+
+func TestOneWayErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		err  string
+	}{
+		{"door of the one-way key", "A V B\n", `door 'V' at line 1`},
+		{"one-way cell in a hex maze", "hex\nA > B\n", "a hex maze can't have one-way cells"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewMaze(strings.NewReader(tt.src))
+			if err == nil {
+				t.Fatalf("no error, want %q", tt.err)
+			}
+			if !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("error %q, want %q in it", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestOneWaySolve(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		// -1 when there is no path
+		cost float64
+	}{
+		{"with the arrow", "A>B\n", 2},
+		{"against the arrow", "A<B\n", -1},
+		{"leaving against the arrow", "#B#\n#^#\n#v#\n#A#\n", -1},
+		{"down the v", "A\nv\nB\n", 2},
+		{"up the v", "B\nv\nA\n", -1},
+		{"around the wrong aisle", "##########\n#A <<<< B#\n## #### ##\n#  >>>>  #\n##########\n", 11},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMaze(strings.NewReader(tt.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := Solve("Dijkstra", m); err != nil {
+				t.Fatal(err)
+			}
+
+			cost := m.Solution.Cost
+			if len(m.Solution.Cells) == 0 {
+				cost = -1
+			}
+			if cost != tt.cost {
+				t.Errorf("cost %g, want %g", cost, tt.cost)
+			}
+
+			// no move of the path goes against a one-way cell
+			p := m.Start
+			for _, c := range m.Solution.Cells {
+				if !m.oneWay(p, c) {
+					t.Errorf("the move %v to %v goes against a one-way cell", p, c)
+				}
+				p = c
+			}
+		})
+	}
+}
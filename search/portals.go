@@ -0,0 +1,144 @@
+package search
+
+import "fmt"
+
+// PortalTiles are the characters of the portals in the maze file. every one
+// of them that is used has to show up exactly twice, standing on one of the
+// two cells the agent can teleport to the other one
+const PortalTiles = "@$%&=+?!"
+
+// DefaultPortalCost is the cost of a teleport when nothing else is set
+const DefaultPortalCost = 1.0
+
+// teleport is the action of a move through a portal
+const teleport = "teleport"
+
+// pairPortals links every portal cell to its partner, it fails when a portal
+// character doesn't show up exactly twice
+func (g *Maze) pairPortals() error {
+	cells := make(map[rune][]Point)
+	var order []rune
+
+	for z := range g.Levels() {
+		for _, row := range g.Floor(z) {
+			for _, w := range row {
+				if w.Portal == 0 {
+					continue
+				}
+				if cells[w.Portal] == nil {
+					order = append(order, w.Portal)
+				}
+				cells[w.Portal] = append(cells[w.Portal], w.State)
+			}
+		}
+	}
+
+	if len(order) == 0 {
+		return nil
+	}
+
+	g.Portals = make(map[Point]Point)
+
+	for _, r := range order {
+		c := cells[r]
+		switch {
+		case len(c) == 1:
+			return fmt.Errorf("portal %q at line %d has no partner", r, c[0].X+1)
+		case len(c) > 2:
+			return fmt.Errorf("portal %q is used %d times, portals come in pairs", r, len(c))
+		}
+
+		g.Portals[c[0]] = c[1]
+		g.Portals[c[1]] = c[0]
+	}
+
+	return nil
+}
+
+// partner is the cell the portal at p teleports to. ok is false when p is no
+// portal or a normal move already gets from p to its partner
+func (g *Maze) partner(p Point) (Point, bool) {
+	q, ok := g.Portals[p]
+	if !ok || g.stepsTo(p, q) {
+		return Point{}, false
+	}
+	return q, true
+}
+
+// stepsTo tells if a move of the maze goes from p to the cell q next to it.
+// a diagonal neighbor is only one without Diagonal when the maze is made of
+// hexes, and a one-way cell or the corner rule can keep the move from q
+func (g *Maze) stepsTo(p, q Point) bool {
+	if p.Z != q.Z {
+		return false
+	}
+
+	dx, dy := abs(p.X-q.X), abs(p.Y-q.Y)
+	switch {
+	case g.Hex:
+		if HexDistance(p, q) != 1 {
+			return false
+		}
+	case g.Diagonal:
+		if max(dx, dy) != 1 {
+			return false
+		}
+	case dx+dy != 1:
+		return false
+	}
+
+	return g.contains(q) && !g.at(q).IsWall && g.oneWay(p, q) && g.pastCorners(p, q)
+}
+
+// isTeleport tells if the move from a cell to another goes through a portal
+func (g *Maze) isTeleport(from, to Point) bool {
+	q, ok := g.partner(from)
+	return ok && q == to
+}
+
+// action names the move from a cell to the next cell of a path, that is
+// a teleport when it goes through a portal
+func (g *Maze) action(from, to Point) string {
+	if g.isTeleport(from, to) {
+		return teleport
+	}
+	if from.Z != to.Z {
+		return stairsAction(from, to)
+	}
+	if g.Hex {
+		return hexAction(from, to)
+	}
+	return actionTo(from, to)
+}
+
+// throughPortals makes the heuristic count with the portals. a path that
+// teleports at least once walks to a portal first and walks to the goal from
+// a portal last, so it costs at least the way to the nearest portal, one
+// teleport and the way from the portal nearest to the goal. the smaller of
+// that and the heuristic itself is still admissible and consistent
+func (g *Maze) throughPortals(h Heuristic) Heuristic {
+	cost := g.PortalCost
+
+	return func(p, goal Point) float64 {
+		direct := h(p, goal)
+
+		toPortal, fromPortal := direct, direct
+		for q := range g.Portals {
+			toPortal = min(toPortal, h(p, q))
+			fromPortal = min(fromPortal, h(q, goal))
+		}
+
+		return min(direct, toPortal+cost+fromPortal)
+	}
+}
+
+// Teleports counts the moves of the solution that went through a portal
+func (s Solution) Teleports() int {
+	n := 0
+	for _, a := range s.Actions {
+		if a == teleport {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,72 @@
+package search
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestPortalErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		err  string
+	}{
+		{"no partner", "A\n@ B\n", `portal '@' at line 2 has no partner`},
+		{"three of them", "A@ @ @B\n", `portal '@' is used 3 times`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewMaze(strings.NewReader(tt.src))
+			if err == nil {
+				t.Fatalf("no error, want %q", tt.err)
+			}
+			if !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("error %q, want %q in it", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestPortalSolve(t *testing.T) {
+	tests := []struct {
+		name       string
+		src        string
+		portalCost float64
+		cost       float64
+		teleports  int
+	}{
+		{"through the wall", "A@#@B\n", 1, 3, 1},
+		{"cheaper than the walk", "A@#@B\n     \n", 3, 5, 1},
+		{"dearer than the walk", "A@#@B\n     \n", 10, 6, 0},
+		{"free, twice", "A@#@$#$B\n", 0, 3, 2},
+		// a step gets there already, the portal is never used
+		{"partners next to each other", "A@@B\n", 0, 3, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"Dijkstra", "AStar", "BiAStar", "JPS", "IDAStar"} {
+				m, err := NewMaze(strings.NewReader(tt.src))
+				if err != nil {
+					t.Fatal(err)
+				}
+				m.PortalCost = tt.portalCost
+				if err := Solve(name, m); err != nil {
+					t.Fatal(err)
+				}
+
+				if got := pathCost(m); math.Abs(got-tt.cost) > 1e-9 {
+					t.Errorf("%s: cost %g, want %g", name, got, tt.cost)
+					continue
+				}
+				checkPath(t, m)
+
+				if got := m.Solution.Teleports(); got != tt.teleports {
+					t.Errorf("%s: %d teleports, want %d", name, got, tt.teleports)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package search
+
+// our container/heap package that we used in the PriorityFrontier
+// requires these method's that we create on the PriorityQueue
+// nodes with the lowest Priority come out first
+
+type PriorityQueue []*Node
+
+func (pq PriorityQueue) Len() int {
+
+	return len(pq)
+}
+
+func (pq PriorityQueue) Less(i, j int) bool {
+
+	// on a tie take the node that is further from the start, for A*
+	// that's the one closer to the goal
+	if pq[i].Priority == pq[j].Priority {
+		return pq[i].Cost > pq[j].Cost
+	}
+
+	return pq[i].Priority < pq[j].Priority
+}
+
+func (pq PriorityQueue) Swap(i, j int) {
+
+	pq[i], pq[j] = pq[j], pq[i]
+	pq[i].index = i
+	pq[j].index = j
+}
+
+func (pq *PriorityQueue) Push(x any) {
+
+	n := pq.Len()
+
+	// convert the x to Node type
+	item := x.(*Node)
+
+	item.index = n
+
+	*pq = append(*pq, item)
+
+}
+
+func (pq *PriorityQueue) Pop() any {
+	old := *pq
+	n := pq.Len()
+
+	item := old[n-1]
+
+	old[n-1] = nil
+
+	item.index = -1
+
+	*pq = old[:n-1]
+
+	return item
+
+}
@@ -0,0 +1,160 @@
+package search
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Searcher is implemented by every algorithm that can solve a maze
+type Searcher interface {
+	Solve()
+}
+
+// Engine is the one search loop shared by all the algorithms.
+// the only thing that changes between DFS, BFS, Dijkstra, GBFS and A*
+// is the Frontier that decides which node gets explored next
+type Engine struct {
+	Name     string
+	Frontier Frontier
+	Game     *Maze
+}
+
+func (e *Engine) Solve() {
+
+	if e.Game.Debug {
+		fmt.Printf("Starting to solve maze using %s...\n", e.Name)
+	}
+
+	e.Game.NumExplored = 0
+	e.Game.Reopened = 0
+	e.Game.Costs = make(map[Point]float64)
+
+	// the expanded states and their costs. in a maze with doors a cell can
+	// be expanded once for every set of keys, Costs only keeps the cheapest
+	closed := make(map[KeyedState]float64)
+
+	// with more than one start every one of them goes into the frontier,
+	// the search then grows from all of them at once
+	for _, p := range e.Game.starts() {
+		start := &Node{
+
+			State:  p,
+			Parent: nil,
+			Action: "",
+		}
+
+		e.Frontier.Add(start)
+		e.Game.CurrentNode = start
+	}
+
+	for {
+
+		if e.Frontier.Empty() {
+
+			return
+		}
+
+		if e.Game.Debug {
+			fmt.Println("Before removing...")
+			for _, val := range e.Frontier.GetFrontier() {
+
+				fmt.Println("Node: ", val.State)
+			}
+
+		}
+
+		currentNode, err := e.Frontier.Remove()
+
+		if err != nil {
+
+			if e.Game.Debug {
+				fmt.Println(err)
+			}
+			return
+		}
+
+		if e.Game.Debug {
+
+			fmt.Println("Removed", currentNode.State)
+			fmt.Println("-------")
+			fmt.Println()
+		}
+
+		e.Game.CurrentNode = currentNode
+		e.Game.NumExplored++
+		closed[currentNode.keyed()] = currentNode.Cost
+		if cost, ok := e.Game.Costs[currentNode.State]; !ok || currentNode.Cost < cost {
+			e.Game.Costs[currentNode.State] = currentNode.Cost
+		}
+		// Have we found the solution?
+		if e.Game.IsGoal(currentNode.State) {
+			e.Game.Solution = currentNode.solution()
+			e.Game.Start = currentNode.root().State
+			e.Game.Explored = append(e.Game.Explored, currentNode.State)
+			break
+		}
+
+		e.Game.Explored = append(e.Game.Explored, currentNode.State)
+
+		// Build animation frame if appropriate.
+		e.Game.frame()
+		for _, child := range e.Game.Neighbors(currentNode) {
+			if e.Frontier.ContainsState(child) {
+				// maybe we found a cheaper way to a node that is still waiting
+				if u, ok := e.Frontier.(Updater); ok {
+					u.Update(child)
+				}
+				continue
+			}
+
+			// a closed node normally stays closed, with an inconsistent
+			// heuristic a cheaper way to it can still show up later so
+			// it is put back into the frontier when Reopen is set
+			if closedCost, ok := closed[child.keyed()]; ok {
+				if !e.Game.Reopen || child.Cost >= closedCost {
+					continue
+				}
+				delete(closed, child.keyed())
+				e.Game.Reopened++
+			}
+
+			e.Frontier.Add(child)
+		}
+
+	}
+
+}
+
+// solution walks from the node back to the start through the parents
+func (n *Node) solution() Solution {
+	var actions []string
+	var cells []Point
+	var keys []Keys
+	cost := n.Cost
+	withKeys := n.Keys != 0
+
+	for n.Parent != nil {
+		// this is traversing child to parent(goal to start)
+		actions = append(actions, n.Action)
+		cells = append(cells, n.State)
+		keys = append(keys, n.Keys)
+		n = n.Parent
+	}
+
+	// rever this(now it becomes start to goal)
+	slices.Reverse(actions)
+	slices.Reverse(cells)
+	slices.Reverse(keys)
+
+	// no key was picked up, the maze has none
+	if !withKeys {
+		keys = nil
+	}
+
+	return Solution{
+		Actions: actions,
+		Cells:   cells,
+		Cost:    cost,
+		Keys:    keys,
+	}
+}
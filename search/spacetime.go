@@ -0,0 +1,138 @@
+package search
+
+import "container/heap"
+
+// wait is the action of staying in the same cell for one time step
+const wait = "wait"
+
+// WaitCost is what staying in a cell for one time step costs
+const WaitCost = 1.0
+
+// timed is a cell at a time step, the state of a search in space and time
+type timed struct {
+	Point
+	T int
+}
+
+// spaceTime is A* over the cells and the time steps. every move takes one
+// time step and waiting in a cell is a move too, so the same cell at another
+// time is another state. blocked says when a cell or a move can't be used,
+// that's how the other agents (or anything else that moves) keep the way clear
+type spaceTime struct {
+	m *Maze
+	h Heuristic
+
+	// blocked tells if moving from a cell to another (the same cell for a
+	// wait) so that the agent is there at time t isn't allowed
+	blocked func(from, to Point, t int) bool
+	// the last time step anything is blocked, after it the time doesn't
+	// change anything any more and every later step counts as the same
+	last int
+	// what is blocked after last repeats every period steps (the obstacles
+	// that go round their routes), 0 when nothing is blocked after last
+	period int
+	// the last time step the goal cell is blocked, the agent can only stay
+	// there for good after it
+	goalLast int
+	// the cells expanded by every plan so far, each one goes into the
+	// maze's Explored once
+	seen map[Point]bool
+	// the time step the plan leaves the start at
+	start int
+}
+
+// plan finds the cheapest way from the start at the start time to the goal,
+// the Depth of the nodes is their time step. it counts the expanded nodes into
+// the maze's NumExplored
+func (s *spaceTime) plan(start, goal Point) (Solution, bool) {
+	m := s.m
+
+	var open PriorityQueue
+	push := func(n *Node) {
+		n.EstimatedCostToGoal = s.h(n.State, goal)
+		n.Priority = n.Cost + n.EstimatedCostToGoal
+		heap.Push(&open, n)
+	}
+
+	push(&Node{State: start, Depth: s.start})
+
+	closed := make(map[timed]bool)
+
+	for open.Len() > 0 {
+		n := heap.Pop(&open).(*Node)
+
+		// past the last blocked step a cell is the same state at every time,
+		// or at every time of the same step of the period
+		state := timed{Point: n.State, T: s.clock(n.Depth)}
+		if closed[state] {
+			continue
+		}
+		closed[state] = true
+
+		m.NumExplored++
+		if !s.seen[n.State] {
+			s.seen[n.State] = true
+			m.Explored = append(m.Explored, n.State)
+		}
+
+		if n.State == goal && n.Depth > s.goalLast {
+			return n.solution(), true
+		}
+
+		moves := m.Neighbors(n)
+		moves = append(moves, &Node{
+			State:  n.State,
+			Parent: n,
+			Action: wait,
+			Cost:   n.Cost + WaitCost,
+			Depth:  n.Depth + 1,
+		})
+
+		for _, child := range moves {
+			if s.blocked(n.State, child.State, child.Depth) {
+				continue
+			}
+			if closed[timed{Point: child.State, T: s.clock(child.Depth)}] {
+				continue
+			}
+			push(child)
+		}
+	}
+
+	return Solution{}, false
+}
+
+// clock is the time step that tells the states of a cell apart, the times
+// that block the same things are the same
+func (s *spaceTime) clock(t int) int {
+	switch {
+	case t <= s.last:
+		return t
+	case s.period > 0:
+		return s.last + 1 + (t-s.last-1)%s.period
+	}
+	return s.last + 1
+}
+
+// Waits counts the time steps the plan stays in the same cell
+func (s Solution) Waits() int {
+	n := 0
+	for _, a := range s.Actions {
+		if a == wait {
+			n++
+		}
+	}
+	return n
+}
+
+// position is where the plan that starts at start is at the time step, it
+// stays at its last cell once the plan is done
+func position(plan Solution, start Point, t int) Point {
+	switch {
+	case t <= 0 || len(plan.Cells) == 0:
+		return start
+	case t > len(plan.Cells):
+		return plan.Cells[len(plan.Cells)-1]
+	}
+	return plan.Cells[t-1]
+}
@@ -0,0 +1,118 @@
+package search
+
+import (
+	"container/heap"
+	"math"
+	"slices"
+)
+
+// IsStart tells if there is a start at p
+func (g *Maze) IsStart(p Point) bool {
+	if len(g.Starts) == 0 {
+		return p == g.Start
+	}
+	return slices.Contains(g.Starts, p)
+}
+
+// starts are the cells a search begins at, every start of the maze
+func (g *Maze) starts() []Point {
+	if len(g.Starts) == 0 {
+		return []Point{g.Start}
+	}
+	return g.Starts
+}
+
+// root is the start the node's path begins at
+func (n *Node) root() *Node {
+	for n.Parent != nil {
+		n = n.Parent
+	}
+	return n
+}
+
+// claim is a start reaching a cell in the sweep of the regions
+type claim struct {
+	p     Point
+	cost  float64
+	start int
+}
+
+// claims is the frontier of the sweep, the cheapest claim first and on a tie
+// the one of the start that comes first in the maze file
+type claims []claim
+
+func (c claims) Len() int { return len(c) }
+
+func (c claims) Less(i, j int) bool {
+	if math.Abs(c[i].cost-c[j].cost) > epsilon {
+		return c[i].cost < c[j].cost
+	}
+	return c[i].start < c[j].start
+}
+
+func (c claims) Swap(i, j int) { c[i], c[j] = c[j], c[i] }
+
+func (c *claims) Push(x any) { *c = append(*c, x.(claim)) }
+
+func (c *claims) Pop() any {
+	old := *c
+	x := old[len(old)-1]
+	*c = old[:len(old)-1]
+	return x
+}
+
+// regions finds the closest start of every cell. it is one Dijkstra sweep
+// that starts from every start at once, the first claim on a cell wins. steps
+// counts the moves instead of their costs, like BFS does. the cells no start
+// can get to belong to none
+func (g *Maze) regions(steps bool) {
+	g.Regions = make(map[Point]int)
+	g.RegionCosts = make(map[Point]float64)
+
+	frontier := &claims{}
+	for i, start := range g.Starts {
+		heap.Push(frontier, claim{p: start, start: i})
+	}
+
+	for frontier.Len() > 0 {
+		c := heap.Pop(frontier).(claim)
+		if _, done := g.Regions[c.p]; done {
+			continue
+		}
+
+		g.Regions[c.p] = c.start
+		g.RegionCosts[c.p] = c.cost
+
+		for _, e := range g.Graph().Edges(c.p) {
+			if _, done := g.Regions[e.End]; done {
+				continue
+			}
+
+			cost := e.Cost
+			if steps {
+				cost = 1
+			}
+			heap.Push(frontier, claim{p: e.End, cost: c.cost + cost, start: c.start})
+		}
+	}
+}
+
+// RegionSizes counts the cells that are closest to each start, nil when the
+// regions weren't made
+func (g *Maze) RegionSizes() []int {
+	if g.Regions == nil {
+		return nil
+	}
+
+	sizes := make([]int, len(g.Starts))
+	for _, i := range g.Regions {
+		sizes[i]++
+	}
+	return sizes
+}
+
+// StartIndex is the place of the start at p in Starts, -1 when there is no
+// start at p
+func (g *Maze) StartIndex(p Point) int {
+	return slices.Index(g.Starts, p)
+}
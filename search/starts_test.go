@@ -0,0 +1,129 @@
+package search
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// reach is the cost of getting from p to every cell the graph of the maze
+// can get to, steps counts the moves instead
+func reach(m *Maze, p Point, steps bool) map[Point]float64 {
+	if !steps {
+		return m.distances(p)
+	}
+
+	costs := map[Point]float64{p: 0}
+	queue := []Point{p}
+	for len(queue) > 0 {
+		q := queue[0]
+		queue = queue[1:]
+		for _, e := range m.Graph().Edges(q) {
+			if _, ok := costs[e.End]; !ok {
+				costs[e.End] = costs[q] + 1
+				queue = append(queue, e.End)
+			}
+		}
+	}
+	return costs
+}
+
+// a search from every start at once finds the path from the closest one
+func TestMultiSource(t *testing.T) {
+	for _, rule := range moveRules {
+		t.Run(rule.name, func(t *testing.T) {
+			m, ok := solveFile(t, "../maze-starts.txt", rule, "Dijkstra")
+			if !ok {
+				t.Fatal("Dijkstra doesn't solve the maze")
+			}
+			if len(m.Starts) < 2 {
+				t.Fatalf("the maze has %d starts", len(m.Starts))
+			}
+
+			want := math.Inf(1)
+			for _, start := range m.Starts {
+				if c, ok := m.distances(start)[m.Goal]; ok {
+					want = min(want, c)
+				}
+			}
+
+			for _, name := range []string{"Dijkstra", "AStar"} {
+				m, ok := solveFile(t, "../maze-starts.txt", rule, name)
+				if !ok {
+					t.Fatalf("%s doesn't solve the maze", name)
+				}
+				if got := pathCost(m); math.Abs(got-want) > 1e-9 {
+					t.Errorf("%s: cost %g, the closest start costs %g", name, got, want)
+				}
+				if m.StartIndex(m.Start) < 0 {
+					t.Errorf("%s: the path begins at %v, not at a start", name, m.Start)
+				}
+				checkPath(t, m)
+			}
+		})
+	}
+}
+
+// every cell belongs to its closest start, on a tie to the one that comes
+// first. BFS counts the moves to get there, Dijkstra their costs
+func TestRegions(t *testing.T) {
+	for _, name := range []string{"BFS", "Dijkstra"} {
+		for _, rule := range moveRules {
+			t.Run(name+"/"+rule.name, func(t *testing.T) {
+				m, ok := solveFile(t, "../maze-starts.txt", rule, name)
+				if !ok {
+					t.Fatalf("%s doesn't solve the maze", name)
+				}
+				a, _ := Lookup(name)
+				steps := a.Has(Steps)
+
+				var costs []map[Point]float64
+				for _, start := range m.Starts {
+					costs = append(costs, reach(m, start, steps))
+				}
+
+				cells := map[Point]bool{}
+				for _, c := range costs {
+					for p := range c {
+						cells[p] = true
+					}
+				}
+				if len(m.Regions) != len(cells) {
+					t.Errorf("%d cells in the regions, %d can be reached", len(m.Regions), len(cells))
+				}
+
+				for p := range cells {
+					best, want := -1, math.Inf(1)
+					for i, c := range costs {
+						if d, ok := c[p]; ok && d < want-epsilon {
+							best, want = i, d
+						}
+					}
+
+					if m.Regions[p] != best || math.Abs(m.RegionCosts[p]-want) > 1e-9 {
+						t.Errorf("%v belongs to start %d for %g, want %d for %g", p, m.Regions[p], m.RegionCosts[p], best, want)
+					}
+				}
+
+				size := 0
+				for _, n := range m.RegionSizes() {
+					size += n
+				}
+				if size != len(cells) {
+					t.Errorf("the regions have %d cells, want %d", size, len(cells))
+				}
+			})
+		}
+	}
+}
+
+func TestMultiSourceRefused(t *testing.T) {
+	m, err := NewMaze(strings.NewReader("A  B  A\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Solve("IDAStar", m)
+	if err == nil || !strings.Contains(err.Error(), "can't search from more than one start") {
+		t.Errorf("error %v, want one about the starts", err)
+	}
+}
@@ -0,0 +1,81 @@
+package search
+
+import "fmt"
+
+func init() {
+	Register(Algorithm{Name: "STAStar", SearchType: STASTAR, New: NewSpaceTimeAstar, Can: Stepwise | Obstacles})
+}
+
+// SpaceTimeAstar finds the cheapest way to the goal past the moving obstacles
+// of the maze. its states are the cells at every time step and besides the
+// moves it can wait in a cell for a step, so it can let a guard walk by
+// before it goes on. the guards go round their routes forever, so the time
+// steps of one round are all it has to tell apart. the agent is done once it
+// gets to the goal, a guard walking over the goal after that doesn't matter
+type SpaceTimeAstar struct {
+	Game *Maze
+}
+
+func NewSpaceTimeAstar(m *Maze) Searcher {
+	return &SpaceTimeAstar{Game: m}
+}
+
+func (a *SpaceTimeAstar) Solve() {
+
+	if a.Game.Debug {
+		fmt.Println("Starting to solve maze using Space-Time A*...")
+	}
+
+	m := a.Game
+	m.NumExplored = 0
+	m.Explored = nil
+	m.Costs = make(map[Point]float64)
+	m.Solution = Solution{}
+
+	period, err := m.ObstaclePeriod()
+	if err != nil {
+		m.Label = err.Error()
+		return
+	}
+
+	if m.obstacleBlocked(m.Start, m.Start, m.StartTime) {
+		m.Label = fmt.Sprintf("Space-time A* found a guard on the start at time %d", m.StartTime)
+		return
+	}
+
+	s := &spaceTime{
+		m:        m,
+		h:        m.heuristic(),
+		blocked:  m.obstacleBlocked,
+		last:     -1,
+		period:   period,
+		goalLast: -1,
+		seen:     make(map[Point]bool),
+		start:    m.StartTime,
+	}
+
+	plan, ok := s.plan(m.Start, m.Goal)
+	if !ok {
+		m.Label = "Space-time A* found the guards always in the way to the goal"
+		return
+	}
+
+	m.Solution = plan
+
+	// the agent and the guards one time step at a time
+	var agent Point
+	m.Agent = &agent
+
+	steps := len(plan.Cells)
+	for t := 0; t <= steps; t++ {
+		agent = position(plan, m.Start, t)
+
+		m.Time = m.StartTime + t
+		m.Label = fmt.Sprintf("Space-time A* time step %d/%d", t, steps)
+		m.frame()
+	}
+
+	m.Agent = nil
+	m.Time = 0
+	m.Label = ""
+}
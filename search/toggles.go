@@ -0,0 +1,91 @@
+package search
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Toggle turns an open cell into a wall or a wall into an open cell while an
+// incremental search (LPA*, D* Lite) is running
+type Toggle struct {
+	// D* Lite flips the cell once the agent has made this many moves,
+	// LPA* has no agent and just flips the toggles in the order of After
+	After int
+	Cell  Point
+}
+
+// ParseToggles reads a list of toggles like "3:4,5 3:4,6 7:2,1", each one is
+// after:row,col and the "after:" can be left out for 0. the toggles can be
+// separated by spaces or ";"
+func (g *Maze) ParseToggles(s string) ([]Toggle, error) {
+	var toggles []Toggle
+
+	for _, field := range strings.Fields(strings.ReplaceAll(s, ";", " ")) {
+		var t Toggle
+
+		cell := field
+		if after, rest, ok := strings.Cut(field, ":"); ok {
+			n, err := strconv.Atoi(after)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("bad toggle %q: the move count must be a number >= 0", field)
+			}
+			t.After = n
+			cell = rest
+		}
+
+		p, ok := parseCell(cell)
+		if !ok {
+			return nil, fmt.Errorf("bad toggle %q: want after:row,col (or after:row,col,floor) in numbers", field)
+		}
+
+		t.Cell = p
+
+		if !g.contains(t.Cell) {
+			return nil, fmt.Errorf("bad toggle %q: the cell is outside the maze", field)
+		}
+		if g.IsStart(t.Cell) || g.IsGoal(t.Cell) {
+			return nil, fmt.Errorf("bad toggle %q: the starts and the goals can't become walls", field)
+		}
+
+		toggles = append(toggles, t)
+	}
+
+	return toggles, nil
+}
+
+// rounds splits the toggles into the groups that are flipped together, in
+// the order they happen
+func rounds(toggles []Toggle) [][]Toggle {
+	sorted := slices.Clone(toggles)
+	slices.SortStableFunc(sorted, func(a, b Toggle) int {
+		return cmp.Compare(a.After, b.After)
+	})
+
+	var r [][]Toggle
+	for i, t := range sorted {
+		if i == 0 || t.After != sorted[i-1].After {
+			r = append(r, nil)
+		}
+		r[len(r)-1] = append(r[len(r)-1], t)
+	}
+
+	return r
+}
+
+// toggleWall flips the cell between wall and open
+func (g *Maze) toggleWall(p Point) {
+	w := g.at(p)
+	w.IsWall = !w.IsWall
+
+	// the walls that closed the short lines have no cost
+	if !w.IsWall && w.Cost < 1 {
+		w.Cost = 1
+	}
+}
+
+func (g *Maze) contains(p Point) bool {
+	return 0 <= p.X && p.X < g.Height && 0 <= p.Y && p.Y < g.Width && 0 <= p.Z && p.Z < g.Levels()
+}
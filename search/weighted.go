@@ -0,0 +1,51 @@
+package search
+
+import "fmt"
+
+func init() {
+	Register(Algorithm{Name: "WAStar", SearchType: WASTAR, New: NewWeightedAstar, Can: Stepwise | Doors | Goals | Starts | Graphs})
+}
+
+// DefaultWeight is the w of weighted A* when the maze has no Weight set
+const DefaultWeight = 2.0
+
+// NewWeightedAstar explores the node with the lowest f = g + w·h first. a w
+// over 1 trusts the heuristic more, so it goes to the goal faster and the
+// path costs at most w times the optimal one
+func NewWeightedAstar(m *Maze) Searcher {
+	w := m.weight(DefaultWeight)
+
+	return &Engine{
+		Name: fmt.Sprintf("Weighted AStar Search (w=%g)", w),
+		Frontier: &PriorityFrontier{
+			Cost: func(n *Node) float64 {
+				n.EstimatedCostToGoal = m.Estimate(n.State)
+				return n.Cost + w*n.EstimatedCostToGoal
+			},
+		},
+		Game: m,
+	}
+}
+
+// weight is the maze's Weight, def when it has none. weights under 1 would
+// make the search ignore the heuristic, so they count as 1
+func (g *Maze) weight(def float64) float64 {
+	if g.Weight <= 0 {
+		return def
+	}
+
+	return max(g.Weight, 1)
+}
+
+// HeuristicWeight is the w the maze's search multiplies h with, so its f is
+// g + w·h. it is 1 for the searches that don't weigh the heuristic
+func (g *Maze) HeuristicWeight() float64 {
+	switch g.SearchType {
+	case WASTAR:
+		return g.weight(DefaultWeight)
+	case ARASTAR:
+		return max(g.passWeight, 1)
+	}
+
+	return 1
+}
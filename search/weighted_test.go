@@ -0,0 +1,62 @@
+package search
+
+import (
+	"fmt"
+	"testing"
+)
+
+// weighted A* finds a path that costs at most w times the optimal one
+func TestWeightedAstarBound(t *testing.T) {
+	for _, file := range []string{"../maze.txt", "../maze-weighted.txt", "../maze-100-steps.txt", "../movingai-rooms.map"} {
+		d, ok := solveFile(t, file, moveRules[1], "Dijkstra")
+		if !ok {
+			t.Fatalf("%s: Dijkstra doesn't solve it", file)
+		}
+		optimal := pathCost(d)
+
+		for _, w := range []float64{1, 1.5, 2, 5} {
+			t.Run(fmt.Sprintf("%s/w=%g", file, w), func(t *testing.T) {
+				m, err := LoadMaze(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				m.Diagonal = true
+				m.Weight = w
+				if err := Solve("WAStar", m); err != nil {
+					t.Fatal(err)
+				}
+				checkPath(t, m)
+
+				if cost := pathCost(m); cost < optimal-1e-9 || cost > w*optimal+1e-9 {
+					t.Errorf("cost %g, want between %g and %g", cost, optimal, w*optimal)
+				}
+			})
+		}
+	}
+}
+
+func TestHeuristicWeight(t *testing.T) {
+	tests := []struct {
+		name       string
+		searchType int
+		weight     float64
+		pass       float64
+		want       float64
+	}{
+		{"weighted A* without a weight", WASTAR, 0, 0, DefaultWeight},
+		{"weighted A*", WASTAR, 3.5, 0, 3.5},
+		{"weighted A* under 1", WASTAR, 0.5, 0, 1},
+		{"ARA* pass", ARASTAR, 3, 2, 2},
+		{"ARA* before its first pass", ARASTAR, 3, 0, 1},
+		{"A*", ASTAR, 3, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Maze{SearchType: tt.searchType, Weight: tt.weight, passWeight: tt.pass}
+			if got := m.HeuristicWeight(); got != tt.want {
+				t.Errorf("w = %g, want %g", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+func init() {
+	Register(Algorithm{Name: "Yen", SearchType: YEN, New: NewYen, Can: Stepwise | Graphs})
+}
+
+// DefaultK is how many paths Yen's algorithm finds when the maze's K is 0
+const DefaultK = 3
+
+// MaxK is the most paths it looks for, every path is a search from every
+// cell of the one before it
+const MaxK = 50
+
+// edge is a move from a cell to another one
+type edge struct {
+	from, to Point
+}
+
+// Yen finds the K cheapest loopless paths from the start to the goal, the
+// cheapest one first. every path after the first one is found from the ones
+// before it: it keeps the first cells of an earlier path (the root), leaves
+// it at one of the cells (the spur) and takes the cheapest way to the goal
+// that doesn't go back into the root or leave the spur the way an earlier
+// path with the same root did. the cheapest of those candidates is the next
+// path
+type Yen struct {
+	Game *Maze
+
+	// the cells every search expanded
+	seen map[Point]bool
+}
+
+func NewYen(m *Maze) Searcher {
+	return &Yen{Game: m}
+}
+
+func (y *Yen) Solve() {
+
+	if y.Game.Debug {
+		fmt.Println("Starting to solve maze using Yen's K shortest paths...")
+	}
+
+	m := y.Game
+	m.NumExplored = 0
+	m.Explored = nil
+	m.Costs = make(map[Point]float64)
+	m.Paths = nil
+	m.Solution = Solution{}
+
+	y.seen = make(map[Point]bool)
+
+	k := m.K
+	if k <= 0 {
+		k = DefaultK
+	}
+
+	first, ok := y.shortest(m.Start, nil, nil)
+	if !ok {
+		return
+	}
+	y.found(first, k)
+
+	var candidates []Solution
+
+	for len(m.Paths) < k {
+		last := m.Paths[len(m.Paths)-1]
+		cells := append([]Point{m.Start}, last.Cells...)
+
+		for i := 0; i < len(cells)-1; i++ {
+			spur := cells[i]
+			root := cells[:i+1]
+
+			// the moves out of the spur that the paths with the same root
+			// already took
+			banned := make(map[edge]bool)
+			for _, p := range m.Paths {
+				other := append([]Point{m.Start}, p.Cells...)
+				if len(other) > i+1 && slices.Equal(other[:i+1], root) {
+					banned[edge{from: spur, to: other[i+1]}] = true
+				}
+			}
+
+			// the root can't be walked into again, the path would loop
+			closed := make(map[Point]bool)
+			for _, p := range root[:i] {
+				closed[p] = true
+			}
+
+			tail, ok := y.shortest(spur, closed, banned)
+			if !ok {
+				continue
+			}
+
+			path := Solution{
+				Actions: slices.Concat(last.Actions[:i], tail.Actions),
+				Cells:   slices.Concat(last.Cells[:i], tail.Cells),
+				Cost:    m.pathCost(root) + tail.Cost,
+			}
+
+			if !containsPath(candidates, path) && !containsPath(m.Paths, path) {
+				candidates = append(candidates, path)
+			}
+		}
+
+		if len(candidates) == 0 {
+			break
+		}
+
+		// the cheapest candidate, the shorter one on a tie
+		best := 0
+		for i, c := range candidates {
+			b := candidates[best]
+			if c.Cost < b.Cost-epsilon || (c.Cost < b.Cost+epsilon && len(c.Cells) < len(b.Cells)) {
+				best = i
+			}
+		}
+
+		y.found(candidates[best], k)
+		candidates = slices.Delete(candidates, best, best+1)
+	}
+
+	m.Solution = m.Paths[0]
+	m.Label = ""
+}
+
+// found keeps the next path and shows it in a frame of its own
+func (y *Yen) found(path Solution, k int) {
+	m := y.Game
+	m.Paths = append(m.Paths, path)
+
+	m.Solution = path
+	m.Label = fmt.Sprintf("Yen path %d/%d: %d steps, cost %g", len(m.Paths), k, len(path.Cells), path.Cost)
+	m.frame()
+}
+
+// shortest is Dijkstra from the cell to the goal that doesn't go into the
+// closed cells or take the banned moves
+func (y *Yen) shortest(from Point, closed map[Point]bool, banned map[edge]bool) (Solution, bool) {
+	m := y.Game
+
+	done := make(map[Point]bool)
+
+	frontier := &PriorityFrontier{
+		Cost: func(n *Node) float64 {
+			return n.Cost
+		},
+	}
+	frontier.Add(&Node{State: from})
+
+	for !frontier.Empty() {
+		n, err := frontier.Remove()
+		if err != nil {
+			break
+		}
+
+		done[n.State] = true
+
+		m.NumExplored++
+		if !y.seen[n.State] {
+			y.seen[n.State] = true
+			m.Explored = append(m.Explored, n.State)
+		}
+
+		if n.State == m.Goal {
+			return n.solution(), true
+		}
+
+		for _, child := range m.Neighbors(n) {
+			if done[child.State] || closed[child.State] || banned[edge{from: n.State, to: child.State}] {
+				continue
+			}
+
+			if frontier.ContainsState(child) {
+				frontier.Update(child)
+			} else {
+				frontier.Add(child)
+			}
+		}
+	}
+
+	return Solution{}, false
+}
+
+// pathCost is what walking the cells one after the other costs, the
+// cheapest move when there is more than one from a cell to the next
+func (g *Maze) pathCost(cells []Point) float64 {
+	graph := g.Graph()
+
+	cost := 0.0
+	for i := 1; i < len(cells); i++ {
+		step := math.Inf(1)
+		for _, e := range graph.Edges(cells[i-1]) {
+			if e.End == cells[i] {
+				step = min(step, e.Cost)
+			}
+		}
+		cost += step
+	}
+	return cost
+}
+
+// containsPath tells if one of the paths goes through the same cells
+func containsPath(paths []Solution, path Solution) bool {
+	for _, p := range paths {
+		if slices.Equal(p.Cells, path.Cells) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// simplePathCosts walks every loopless path from the start to the goal and
+// returns their costs, the cheapest first
+func simplePathCosts(m *Maze) []float64 {
+	var costs []float64
+	on := map[Point]bool{m.Start: true}
+
+	var walk func(p Point, cost float64)
+	walk = func(p Point, cost float64) {
+		if p == m.Goal {
+			costs = append(costs, cost)
+			return
+		}
+		for _, e := range m.Graph().Edges(p) {
+			if on[e.End] {
+				continue
+			}
+			on[e.End] = true
+			walk(e.End, cost+e.Cost)
+			on[e.End] = false
+		}
+	}
+	walk(m.Start, 0)
+
+	slices.Sort(costs)
+	return costs
+}
+
+// the paths of Yen's algorithm are loopless, different from each other and
+// legal, and they are the K cheapest loopless paths in order
+func TestYen(t *testing.T) {
+	small := "#######\n#A    #\n# # # #\n#   3 #\n# # # #\n#    B#\n#######\n"
+
+	files := []string{"", "../maze-routes.txt", "../graph-network.dot", "../graph-metro.json"}
+
+	for _, file := range files {
+		for _, k := range []int{1, 3, 8} {
+			name := filepath.Base(file)
+			if file == "" {
+				name = "small"
+			}
+
+			t.Run(fmt.Sprint(name, "/K=", k), func(t *testing.T) {
+				load := func() *Maze {
+					var m *Maze
+					var err error
+					if file == "" {
+						m, err = NewMaze(strings.NewReader(small))
+					} else {
+						m, err = LoadMaze(file)
+					}
+					if err != nil {
+						t.Fatal(err)
+					}
+					return m
+				}
+
+				m := load()
+				m.K = k
+				if err := Solve("Yen", m); err != nil {
+					t.Fatal(err)
+				}
+
+				want := simplePathCosts(load())
+				want = want[:min(k, len(want))]
+				if len(m.Paths) != len(want) {
+					t.Fatalf("%d paths, want %d", len(m.Paths), len(want))
+				}
+
+				for i, path := range m.Paths {
+					if math.Abs(path.Cost-want[i]) > 1e-9 {
+						t.Errorf("path %d costs %g, want %g", i+1, path.Cost, want[i])
+					}
+
+					cells := append([]Point{m.Start}, path.Cells...)
+					for j, c := range cells {
+						if slices.Contains(cells[:j], c) {
+							t.Errorf("path %d goes through %v twice", i+1, c)
+						}
+					}
+
+					for _, other := range m.Paths[:i] {
+						if slices.Equal(other.Cells, path.Cells) {
+							t.Errorf("path %d is there twice", i+1)
+						}
+					}
+
+					m.Solution = path
+					checkPath(t, m)
+				}
+			})
+		}
+	}
+}